@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DeleteMeRequest struct {
+	Password string `json:"password" binding:"required,printascii"`
+}
+
+type deleteMeResponse struct {
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// deleteMe only schedules the deletion. the profile is hidden until the grace
+// period is over, and logging in before then restores it.
+func (server *Server) deleteMe(context *gin.Context) {
+	var req DeleteMeRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	err := util.VerifyPassword(req.Password, authorizedUser.PasswordHash)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		err := queries.SoftDeleteUser(context, authorizedUser.ID)
+		if err != nil {
+			return err
+		}
+
+		return queries.BlockUserSessions(context, authorizedUser.ID)
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.clearRefreshTokenCookie(context)
+
+	context.JSON(http.StatusOK, deleteMeResponse{
+		PurgeAt: time.Now().Add(server.config.AccountDeletionGracePeriod),
+	})
+}
+
+// runAccountPurger permanently removes accounts whose grace period is over. it
+// runs for as long as the server does.
+func (server *Server) runAccountPurger() {
+	ticker := time.NewTicker(server.config.AccountPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		server.purgeDeletedAccounts(context.Background())
+		<-ticker.C
+	}
+}
+
+// accountGone tells if a deleted account can no longer be restored, because its
+// grace period is over or the purger already started on it. logging in to such
+// an account fails like for one that doesn't exist
+func (server *Server) accountGone(user database.User) bool {
+	if !user.DeletedAt.Valid {
+		return false
+	}
+
+	return user.PurgingAt.Valid || !user.DeletedAt.Time.After(server.graceStart())
+}
+
+// graceStart is the oldest deleted_at that is still in its grace period
+func (server *Server) graceStart() time.Time {
+	return time.Now().Add(-server.config.AccountDeletionGracePeriod)
+}
+
+func (server *Server) purgeDeletedAccounts(ctx context.Context) {
+	deletedBefore := sql.NullTime{
+		Time:  server.graceStart(),
+		Valid: true,
+	}
+
+	userIDs, err := server.database.GetUsersDeletedBefore(ctx, deletedBefore)
+	if err != nil {
+		fmt.Println("failed to list deleted accounts:", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		err := server.purgeAccount(ctx, userID, deletedBefore)
+		if err != nil {
+			fmt.Println("failed to purge account", userID, err)
+		}
+	}
+}
+
+// purgeAccount claims the account first, from then on a login can't restore it,
+// and only then deletes the images and exports. the rows are the only record of
+// which objects exist, so if any of them fails the claimed account is left for
+// the next run. everything else the user owns goes with the user row, through
+// the foreign keys.
+func (server *Server) purgeAccount(ctx context.Context, userID uuid.UUID, deletedBefore sql.NullTime) error {
+	// the deleted_at check leaves out an account restored since it was listed
+	claimed, err := server.database.ClaimUserPurge(ctx, database.ClaimUserPurgeParams{
+		ID:        userID,
+		DeletedAt: deletedBefore,
+	})
+	if err != nil {
+		return err
+	}
+
+	if claimed == 0 {
+		return nil
+	}
+
+	imageKeys, err := server.database.GetPostImageKeysByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range imageKeys {
+		err := server.s3Controller.Delete(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	user, err := server.database.GetUserById(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarKey != "" {
+		err := server.s3Controller.Delete(ctx, user.AvatarKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	exports, err := server.database.GetDataExportsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, export := range exports {
+		err := server.deleteDataExport(ctx, export)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = server.database.PurgeUser(ctx, userID)
+	return err
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UpdateUsersRoleUri struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type UpdateUsersRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user moderator admin"`
+}
+
+type updateUsersRoleResponse struct {
+	Role string                `json:"role"`
+	User database.UserResponse `json:"user"`
+}
+
+func (server *Server) updateUsersRole(context *gin.Context) {
+	var uri UpdateUsersRoleUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req UpdateUsersRoleRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// an admin demoting themselves could leave nobody able to undo it
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+	if authorizedUser.ID == id {
+		err := errors.New("you can't change your own role")
+		context.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	user, err := server.database.UpdateUsersRole(context, database.UpdateUsersRoleParams{
+		Role: req.Role,
+		ID:   id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, updateUsersRoleResponse{
+		Role: user.Role,
+		User: user.MakeResponse(),
+	})
+}
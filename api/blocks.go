@@ -0,0 +1,201 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var errBlocked = errors.New("you can't interact with this user")
+
+// checkNotBlocked sends the error response itself, the handler should only go
+// on if it returns true. blocks work both ways and the response doesn't say
+// which of the two users blocked the other
+func (server *Server) checkNotBlocked(context *gin.Context, userID uuid.UUID, otherUserID uuid.UUID) bool {
+	if userID == otherUserID {
+		return true
+	}
+
+	blocked, err := server.database.IsBlockedBetween(context, database.IsBlockedBetweenParams{
+		UserID:        userID,
+		BlockedUserID: otherUserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if blocked {
+		context.JSON(http.StatusForbidden, errorResponse(errBlocked))
+		return false
+	}
+
+	return true
+}
+
+type BlockUserUri struct {
+	UserID string `uri:"id" binding:"required,uuid"`
+}
+
+// blocking also drops every follow and follow request between the two users,
+// in both directions
+func (server *Server) blockUser(context *gin.Context) {
+	var uri BlockUserUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	blockedUserID, err := uuid.Parse(uri.UserID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	if authorizedUser.ID == blockedUserID {
+		context.Status(http.StatusBadRequest)
+		return
+	}
+
+	var block database.Block
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		var err error
+		block, err = queries.BlockUser(context, database.BlockUserParams{
+			UserID:        authorizedUser.ID,
+			BlockedUserID: blockedUserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = queries.DeleteFollowsBetween(context, database.DeleteFollowsBetweenParams{
+			UserID:         authorizedUser.ID,
+			FollowedUserID: blockedUserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = queries.RemoveAuthorsFromTimelinesBetween(context, database.RemoveAuthorsFromTimelinesBetweenParams{
+			UserID:   authorizedUser.ID,
+			AuthorID: blockedUserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return queries.DeleteFollowRequestsBetween(context, database.DeleteFollowRequestsBetweenParams{
+			UserID:          authorizedUser.ID,
+			RequestedUserID: blockedUserID,
+		})
+	})
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			switch err.Code.Name() {
+			case "unique_violation":
+				context.JSON(http.StatusConflict, errorResponse(err))
+				return
+			case "foreign_key_violation":
+				context.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusCreated, block)
+}
+
+func (server *Server) unblockUser(context *gin.Context) {
+	var uri BlockUserUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	blockedUserID, err := uuid.Parse(uri.UserID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	rowsAffected, err := server.database.UnblockUser(context, database.UnblockUserParams{
+		UserID:        authorizedUser.ID,
+		BlockedUserID: blockedUserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rowsAffected == 0 {
+		context.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	context.Status(http.StatusOK)
+}
+
+type GetBlockedUsersRequest struct {
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
+}
+
+func (server *Server) getBlockedUsers(context *gin.Context) {
+	var req GetBlockedUsersRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	arg := database.GetBlockedUsersParams{
+		UserID: authorizedUser.ID,
+		Limit:  req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	users, err := server.database.GetBlockedUsers(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.UserResponse, 0)
+
+	for _, user := range users {
+		res = append(res, user.MakeResponse())
+	}
+
+	nextCursor := ""
+	if len(users) == int(req.PageSize) {
+		last := users[len(users)-1]
+		nextCursor = util.Cursor{CreatedAt: last.BlockedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
+}
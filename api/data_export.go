@@ -0,0 +1,464 @@
+package api
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/realtime"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	dataExportStatusPending = "pending"
+	dataExportStatusReady   = "ready"
+)
+
+// follow lists are read in pages of this size while building an export
+const dataExportPageSize = 500
+
+var (
+	errDataExportInProgress = errors.New("a data export is already in progress")
+	errDataExportNotReady   = errors.New("data export is not ready")
+	errDataExportExpired    = errors.New("data export has expired")
+)
+
+// exportedProfile holds more than UserResponse does, since it's only ever
+// shown to the user themselves
+type exportedProfile struct {
+	database.UserResponse
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role"`
+}
+
+func dataExportKey(exportID uuid.UUID) string {
+	return "exports/" + exportID.String() + ".zip"
+}
+
+// requestDataExport only queues the export, building it can take a while for
+// users with a lot of images. the user is notified once it's ready.
+func (server *Server) requestDataExport(context *gin.Context) {
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	export, err := server.database.CreateDataExport(context, database.CreateDataExportParams{
+		ID:     uuid.New(),
+		UserID: authorizedUser.ID,
+	})
+	if err != nil {
+		// only one pending export per user is allowed by a unique index
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code.Name() == "unique_violation" {
+				context.JSON(http.StatusConflict, errorResponse(errDataExportInProgress))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	go server.runDataExport(export)
+
+	context.JSON(http.StatusAccepted, export.MakeResponse())
+}
+
+func (server *Server) getDataExports(context *gin.Context) {
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	exports, err := server.database.GetDataExportsByUser(context, authorizedUser.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.DataExportResponse, 0)
+	for _, export := range exports {
+		res = append(res, export.MakeResponse())
+	}
+
+	context.JSON(http.StatusOK, res)
+}
+
+type DownloadDataExportRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+func (server *Server) downloadDataExport(context *gin.Context) {
+	var req DownloadDataExportRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	export, err := server.database.GetDataExportById(context, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// someone else's export is reported as missing, not as forbidden
+	if export.UserID != authorizedUser.ID {
+		context.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	if export.Status != dataExportStatusReady {
+		context.JSON(http.StatusConflict, errorResponse(errDataExportNotReady))
+		return
+	}
+
+	if time.Now().After(export.ExpiresAt.Time) {
+		context.JSON(http.StatusGone, errorResponse(errDataExportExpired))
+		return
+	}
+
+	body, err := server.s3Controller.Download(context, export.ObjectKey.String)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer body.Close()
+
+	context.DataFromReader(http.StatusOK, -1, "application/zip", body, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="letscube-export-%s.zip"`, export.CreatedAt.Format("2006-01-02")),
+	})
+}
+
+// resumeDataExports restarts the exports that were still pending when the
+// server went down
+func (server *Server) resumeDataExports() {
+	exports, err := server.database.GetPendingDataExports(context.Background())
+	if err != nil {
+		fmt.Println("failed to list pending data exports:", err)
+		return
+	}
+
+	for _, export := range exports {
+		go server.runDataExport(export)
+	}
+}
+
+func (server *Server) runDataExport(export database.DataExport) {
+	ctx := context.Background()
+
+	err := server.buildDataExport(ctx, export)
+	if err != nil {
+		fmt.Println("data export", export.ID, "failed:", err)
+
+		err := server.database.FailDataExport(ctx, export.ID)
+		if err != nil {
+			fmt.Println("failed to mark data export", export.ID, "as failed:", err)
+		}
+	}
+}
+
+func (server *Server) buildDataExport(ctx context.Context, export database.DataExport) error {
+	user, err := server.database.GetUserById(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+
+	// the archive goes through a temporary file, images alone can be too big
+	// to keep the whole thing in memory
+	file, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	err = server.writeDataExport(ctx, archive, user)
+	if err != nil {
+		return err
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	key := dataExportKey(export.ID)
+
+	_, err = server.s3Controller.UploadObject(ctx, file, key, "application/zip")
+	if err != nil {
+		return err
+	}
+
+	completed, err := server.database.CompleteDataExport(ctx, database.CompleteDataExportParams{
+		ID:        export.ID,
+		ObjectKey: sql.NullString{String: key, Valid: true},
+		ExpiresAt: sql.NullTime{Time: time.Now().Add(server.config.DataExportDuration), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	server.hub.SendToUser(user.ID, realtime.Event{
+		Type: realtime.EventDataExportReady,
+		Data: completed.MakeResponse(),
+	})
+
+	err = server.sendDataExportEmail(user, completed)
+	if err != nil {
+		fmt.Println("failed to send data export email:", err)
+	}
+
+	return nil
+}
+
+func (server *Server) writeDataExport(ctx context.Context, archive *zip.Writer, user database.User) error {
+	err := writeJSONToArchive(archive, "profile.json", exportedProfile{
+		UserResponse:  user.MakeResponse(),
+		Email:         user.Email,
+		EmailVerified: user.IsVerified,
+		Role:          user.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarKey != "" {
+		err := server.copyImageToArchive(ctx, archive, user.AvatarKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	posts, err := server.database.GetAllPostsByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONToArchive(archive, "posts.json", posts)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		for _, key := range post.ImageKeys {
+			err := server.copyImageToArchive(ctx, archive, key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	comments, err := server.database.GetAllCommentsByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONToArchive(archive, "comments.json", comments)
+	if err != nil {
+		return err
+	}
+
+	replies, err := server.database.GetAllRepliesByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONToArchive(archive, "replies.json", replies)
+	if err != nil {
+		return err
+	}
+
+	messages, err := server.database.GetAllMessagesByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	exportedMessages := make([]database.MessageResponse, 0, len(messages))
+	for _, message := range messages {
+		exportedMessages = append(exportedMessages, message.MakeResponse())
+	}
+
+	err = writeJSONToArchive(archive, "messages.json", exportedMessages)
+	if err != nil {
+		return err
+	}
+
+	followers, err := server.getAllFollowers(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONToArchive(archive, "followers.json", followers)
+	if err != nil {
+		return err
+	}
+
+	following, err := server.getAllFollowing(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONToArchive(archive, "following.json", following)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := server.database.GetAllSessionsByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	// refresh tokens stay out of the archive, SessionResponse doesn't have them
+	exportedSessions := make([]database.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		exportedSessions = append(exportedSessions, session.MakeResponse())
+	}
+
+	return writeJSONToArchive(archive, "sessions.json", exportedSessions)
+}
+
+func (server *Server) getAllFollowers(ctx context.Context, userID uuid.UUID) ([]database.UserResponse, error) {
+	res := make([]database.UserResponse, 0)
+
+	for offset := int32(0); ; offset += dataExportPageSize {
+		users, err := server.database.GetFollowers(ctx, database.GetFollowersParams{
+			FollowedUserID: userID,
+			Limit:          dataExportPageSize,
+			Offset:         offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			res = append(res, user.MakeResponse())
+		}
+
+		if len(users) < dataExportPageSize {
+			return res, nil
+		}
+	}
+}
+
+func (server *Server) getAllFollowing(ctx context.Context, userID uuid.UUID) ([]database.UserResponse, error) {
+	res := make([]database.UserResponse, 0)
+
+	for offset := int32(0); ; offset += dataExportPageSize {
+		users, err := server.database.GetFollowing(ctx, database.GetFollowingParams{
+			UserID: userID,
+			Limit:  dataExportPageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			res = append(res, user.MakeResponse())
+		}
+
+		if len(users) < dataExportPageSize {
+			return res, nil
+		}
+	}
+}
+
+func (server *Server) copyImageToArchive(ctx context.Context, archive *zip.Writer, key string) error {
+	image, err := server.s3Controller.Download(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer image.Close()
+
+	writer, err := archive.Create("images/" + key)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, image)
+	return err
+}
+
+func writeJSONToArchive(archive *zip.Writer, name string, data any) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+func (server *Server) sendDataExportEmail(user database.User, export database.DataExport) error {
+	body := fmt.Sprintf(
+		"Hi %s,\n\nthe export of your data is ready. you can download it from your account settings:\n\n%s/settings/exports\n\nThe download is available until %s.",
+		user.Username,
+		server.config.AppURL,
+		export.ExpiresAt.Time.Format(time.RFC1123),
+	)
+
+	return server.mailSender.Send(user.Email, "Your data export is ready", body)
+}
+
+// runDataExportCleanup deletes exports once their download expires. it shares
+// the interval with the account purger
+func (server *Server) runDataExportCleanup() {
+	ticker := time.NewTicker(server.config.AccountPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		server.deleteExpiredDataExports(context.Background())
+		<-ticker.C
+	}
+}
+
+func (server *Server) deleteExpiredDataExports(ctx context.Context) {
+	exports, err := server.database.GetExpiredDataExports(ctx)
+	if err != nil {
+		fmt.Println("failed to list expired data exports:", err)
+		return
+	}
+
+	for _, export := range exports {
+		err := server.deleteDataExport(ctx, export)
+		if err != nil {
+			fmt.Println("failed to delete data export", export.ID, err)
+		}
+	}
+}
+
+func (server *Server) deleteDataExport(ctx context.Context, export database.DataExport) error {
+	if export.ObjectKey.Valid {
+		err := server.s3Controller.Delete(ctx, export.ObjectKey.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	return server.database.DeleteDataExport(ctx, export.ID)
+}
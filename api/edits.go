@@ -0,0 +1,415 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"slices"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	errNotAuthor         = errors.New("only the author can edit this")
+	errNothingToEdit     = errors.New("nothing to edit")
+	errEmptyTextContent  = errors.New("text content can not be empty")
+	errInvalidImageIndex = errors.New("there is no image with that index")
+	errTooManyImages     = errors.New("a post can have at most 5 images")
+)
+
+const maxPostImages = 5
+
+// checkAuthor sends the error response itself, the handler should only go on
+// if it returns true. unlike deleting, moderators can not edit someone else's
+// content
+func checkAuthor(context *gin.Context, ownerID uuid.UUID) bool {
+	user := context.MustGet(authorizationUserKey).(database.User)
+	if user.ID != ownerID {
+		context.JSON(http.StatusForbidden, errorResponse(errNotAuthor))
+		return false
+	}
+
+	return true
+}
+
+type EditUri struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// every field is optional, but at least one has to be sent. remove_images are
+// the indices of the images to drop, new images are added after the ones kept
+type UpdatePostRequest struct {
+	TextContent  *string                 `form:"text_content" binding:"omitempty,max=500"`
+	RemoveImages []int                   `form:"remove_images[]" binding:"max=5"`
+	ImageContent []*multipart.FileHeader `form:"image_content[]" binding:"max=5"`
+}
+
+func (server *Server) updatePost(context *gin.Context) {
+	var uri EditUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req UpdatePostRequest
+	if err := context.ShouldBind(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.TextContent == nil && len(req.RemoveImages) == 0 && len(req.ImageContent) == 0 {
+		context.JSON(http.StatusBadRequest, errorResponse(errNothingToEdit))
+		return
+	}
+
+	if req.TextContent != nil && *req.TextContent == "" {
+		context.JSON(http.StatusBadRequest, errorResponse(errEmptyTextContent))
+		return
+	}
+
+	for _, file := range req.ImageContent {
+		if !slices.Contains(SupportedImageTypes, file.Header.Get("Content-Type")) {
+			context.AbortWithStatus(http.StatusUnsupportedMediaType)
+			return
+		}
+	}
+
+	post, err := server.database.GetPostById(context, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !checkAuthor(context, post.UserID) {
+		return
+	}
+
+	for _, index := range req.RemoveImages {
+		if index < 0 || index >= len(post.ImageKeys) {
+			context.JSON(http.StatusBadRequest, errorResponse(errInvalidImageIndex))
+			return
+		}
+	}
+
+	imageKeys := make([]string, 0, len(post.ImageKeys)+len(req.ImageContent))
+	for i, key := range post.ImageKeys {
+		if !slices.Contains(req.RemoveImages, i) {
+			imageKeys = append(imageKeys, key)
+		}
+	}
+
+	if len(imageKeys)+len(req.ImageContent) > maxPostImages {
+		context.JSON(http.StatusBadRequest, errorResponse(errTooManyImages))
+		return
+	}
+
+	revisionID, err := uuid.NewRandom()
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the old images are left alone, the revision keeps pointing to them. they
+	// are deleted together with the post
+	uploadedKeys, errorProcessingImagesCounter := server.uploadPostImages(context, req.ImageContent, func(index int) string {
+		return postRevisionImageKey(post.ID, revisionID, index)
+	})
+	imageKeys = append(imageKeys, uploadedKeys...)
+
+	textContent := post.TextContent
+	if req.TextContent != nil {
+		textContent = *req.TextContent
+	}
+
+	var updatedPost database.Post
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		_, err := queries.CreatePostRevision(context, database.CreatePostRevisionParams{
+			ID:          revisionID,
+			PostID:      post.ID,
+			TextContent: post.TextContent,
+			ImageCount:  post.ImageCount,
+			ImageKeys:   post.ImageKeys,
+		})
+		if err != nil {
+			return err
+		}
+
+		updatedPost, err = queries.UpdatePost(context, database.UpdatePostParams{
+			ID:          post.ID,
+			TextContent: textContent,
+			ImageCount:  int32(len(imageKeys)),
+			ImageKeys:   imageKeys,
+		})
+		return err
+	})
+	if err != nil {
+		server.deletePostImages(uploadedKeys)
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{
+		"post":             updatedPost,
+		"number_of_errors": errorProcessingImagesCounter,
+	})
+}
+
+type UpdateCommentRequest struct {
+	Content string `json:"content" binding:"required,max=200"`
+}
+
+func (server *Server) updateComment(context *gin.Context) {
+	var uri EditUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	comment, err := server.database.GetCommentById(context, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !checkAuthor(context, comment.UserID) {
+		return
+	}
+
+	var updatedComment database.Comment
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		revisionID, err := uuid.NewRandom()
+		if err != nil {
+			return err
+		}
+
+		_, err = queries.CreateCommentRevision(context, database.CreateCommentRevisionParams{
+			ID:        revisionID,
+			CommentID: comment.ID,
+			Content:   comment.Content,
+		})
+		if err != nil {
+			return err
+		}
+
+		updatedComment, err = queries.UpdateComment(context, database.UpdateCommentParams{
+			ID:      comment.ID,
+			Content: req.Content,
+		})
+		return err
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, updatedComment)
+}
+
+type UpdateReplyRequest struct {
+	Content string `json:"content" binding:"required,max=200"`
+}
+
+func (server *Server) updateReply(context *gin.Context) {
+	var uri EditUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req UpdateReplyRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reply, err := server.database.GetReplyById(context, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !checkAuthor(context, reply.UserID) {
+		return
+	}
+
+	var updatedReply database.Reply
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		revisionID, err := uuid.NewRandom()
+		if err != nil {
+			return err
+		}
+
+		_, err = queries.CreateReplyRevision(context, database.CreateReplyRevisionParams{
+			ID:      revisionID,
+			ReplyID: reply.ID,
+			Content: reply.Content,
+		})
+		if err != nil {
+			return err
+		}
+
+		updatedReply, err = queries.UpdateReply(context, database.UpdateReplyParams{
+			ID:      reply.ID,
+			Content: req.Content,
+		})
+		return err
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, updatedReply)
+}
+
+// revisions hold what the content looked like before each edit, newest first.
+// only the author and moderators get to see them
+func (server *Server) getPostRevisions(context *gin.Context) {
+	var uri EditUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, err := server.database.GetPostById(context, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !canModerate(context, post.UserID) {
+		context.Status(http.StatusForbidden)
+		return
+	}
+
+	revisions, err := server.database.GetPostRevisions(context, post.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, revisions)
+}
+
+func (server *Server) getCommentRevisions(context *gin.Context) {
+	var uri EditUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	comment, err := server.database.GetCommentById(context, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !canModerate(context, comment.UserID) {
+		context.Status(http.StatusForbidden)
+		return
+	}
+
+	revisions, err := server.database.GetCommentRevisions(context, comment.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, revisions)
+}
+
+func (server *Server) getReplyRevisions(context *gin.Context) {
+	var uri EditUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reply, err := server.database.GetReplyById(context, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !canModerate(context, reply.UserID) {
+		context.Status(http.StatusForbidden)
+		return
+	}
+
+	revisions, err := server.database.GetReplyRevisions(context, reply.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, revisions)
+}
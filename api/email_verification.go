@@ -0,0 +1,138 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/token"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	errInvalidVerificationToken = errors.New("invalid or expired verification token")
+	errEmailAlreadyVerified     = errors.New("email is already verified")
+)
+
+// sendVerificationEmail invalidates any previous token of the user before issuing
+// a new one, so only the latest email can be used
+func (server *Server) sendVerificationEmail(context *gin.Context, user database.User) error {
+	verificationToken, err := util.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	err = server.database.InvalidateEmailVerificationTokens(context, user.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = server.database.CreateEmailVerificationToken(context, database.CreateEmailVerificationTokenParams{
+		ID:        id,
+		UserID:    user.ID,
+		TokenHash: util.HashToken(verificationToken),
+		ExpiresAt: time.Now().Add(server.config.EmailVerificationTokenDuration),
+	})
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nconfirm your email address by opening the link below:\n\n%s/verify-email?token=%s\n\nThe link expires in %s.",
+		user.Username,
+		server.config.AppURL,
+		verificationToken,
+		server.config.EmailVerificationTokenDuration,
+	)
+
+	return server.mailSender.Send(user.Email, "Verify your email", body)
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (server *Server) verifyEmail(context *gin.Context) {
+	var req VerifyEmailRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	verificationToken, err := server.database.GetEmailVerificationTokenByHash(context, util.HashToken(req.Token))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusBadRequest, errorResponse(errInvalidVerificationToken))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if verificationToken.UsedAt.Valid || time.Now().After(verificationToken.ExpiresAt) {
+		context.JSON(http.StatusBadRequest, errorResponse(errInvalidVerificationToken))
+		return
+	}
+
+	var user database.User
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		rowsAffected, err := queries.UseEmailVerificationToken(context, verificationToken.ID)
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return errInvalidVerificationToken
+		}
+
+		user, err = queries.VerifyUsersEmail(context, verificationToken.UserID)
+		return err
+	})
+	if err != nil {
+		if err == errInvalidVerificationToken {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, user.MakeResponse())
+}
+
+func (server *Server) resendVerificationEmail(context *gin.Context) {
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+
+	user, err := server.database.GetUserById(context, authorizationPayload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if user.IsVerified {
+		context.JSON(http.StatusConflict, errorResponse(errEmailAlreadyVerified))
+		return
+	}
+
+	err = server.sendVerificationEmail(context, user)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.Status(http.StatusOK)
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/realtime"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var errAlreadyFollowing = errors.New("you are already following this user")
+
+// requestFollow is what followUser does for private accounts, the follow only
+// happens once the owner approves it
+func (server *Server) requestFollow(context *gin.Context, userID uuid.UUID, requestedUserID uuid.UUID) {
+	following, err := server.database.IsFollowing(context, database.IsFollowingParams{
+		UserID:         userID,
+		FollowedUserID: requestedUserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if following {
+		context.JSON(http.StatusConflict, errorResponse(errAlreadyFollowing))
+		return
+	}
+
+	request, err := server.database.CreateFollowRequest(context, database.CreateFollowRequestParams{
+		UserID:          userID,
+		RequestedUserID: requestedUserID,
+	})
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code.Name() == "unique_violation" {
+				context.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.hub.SendToUser(requestedUserID, realtime.Event{
+		Type: realtime.EventFollowRequest,
+		Data: request,
+	})
+
+	context.JSON(http.StatusAccepted, request)
+}
+
+type GetFollowRequestsRequest struct {
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
+}
+
+// getReceivedFollowRequests lists the users waiting for the caller to approve them
+func (server *Server) getReceivedFollowRequests(context *gin.Context) {
+	var req GetFollowRequestsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	arg := database.GetReceivedFollowRequestsParams{
+		RequestedUserID: authorizedUser.ID,
+		Limit:           req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	users, err := server.database.GetReceivedFollowRequests(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.UserResponse, 0)
+
+	for _, user := range users {
+		res = append(res, user.MakeResponse())
+	}
+
+	nextCursor := ""
+	if len(users) == int(req.PageSize) {
+		last := users[len(users)-1]
+		nextCursor = util.Cursor{CreatedAt: last.RequestedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
+}
+
+// getSentFollowRequests lists the private accounts the caller is waiting on
+func (server *Server) getSentFollowRequests(context *gin.Context) {
+	var req GetFollowRequestsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	arg := database.GetSentFollowRequestsParams{
+		UserID: authorizedUser.ID,
+		Limit:  req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	users, err := server.database.GetSentFollowRequests(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.UserResponse, 0)
+
+	for _, user := range users {
+		res = append(res, user.MakeResponse())
+	}
+
+	nextCursor := ""
+	if len(users) == int(req.PageSize) {
+		last := users[len(users)-1]
+		nextCursor = util.Cursor{CreatedAt: last.RequestedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
+}
+
+type FollowRequestUri struct {
+	UserID string `uri:"id" binding:"required,uuid"`
+}
+
+func (server *Server) approveFollowRequest(context *gin.Context) {
+	var uri FollowRequestUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	var follow database.Follow
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		rowsAffected, err := queries.DeleteFollowRequest(context, database.DeleteFollowRequestParams{
+			UserID:          userID,
+			RequestedUserID: authorizedUser.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+
+		follow, err = queries.FollowUser(context, database.FollowUserParams{
+			UserID:         userID,
+			FollowedUserID: authorizedUser.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return backfillTimeline(context, queries, userID, authorizedUser.ID)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.hub.SendToUser(userID, realtime.Event{
+		Type: realtime.EventFollowRequestApproved,
+		Data: follow,
+	})
+
+	context.JSON(http.StatusCreated, follow)
+}
+
+func (server *Server) declineFollowRequest(context *gin.Context) {
+	var uri FollowRequestUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	rowsAffected, err := server.database.DeleteFollowRequest(context, database.DeleteFollowRequestParams{
+		UserID:          userID,
+		RequestedUserID: authorizedUser.ID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rowsAffected == 0 {
+		context.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	context.Status(http.StatusOK)
+}
@@ -1,10 +1,13 @@
 package api
 
 import (
+	"database/sql"
 	"net/http"
 
 	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/realtime"
 	"github.com/dqrk0jeste/letscube-backend/token"
+	"github.com/dqrk0jeste/letscube-backend/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -34,12 +37,45 @@ func (server *Server) followUser(context *gin.Context) {
 		return
 	}
 
+	if !server.checkNotBlocked(context, authorizationPayload.UserID, followedUserID) {
+		return
+	}
+
+	followedUser, err := server.database.GetUserById(context, followedUserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if followedUser.DeletedAt.Valid {
+		context.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	if followedUser.IsPrivate {
+		server.requestFollow(context, authorizationPayload.UserID, followedUserID)
+		return
+	}
+
 	arg := database.FollowUserParams{
 		FollowedUserID: followedUserID,
 		UserID:         authorizationPayload.UserID,
 	}
 
-	follow, err := server.database.FollowUser(context, arg)
+	var follow database.Follow
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		var err error
+		follow, err = queries.FollowUser(context, arg)
+		if err != nil {
+			return err
+		}
+
+		return backfillTimeline(context, queries, follow.UserID, follow.FollowedUserID)
+	})
 	if err != nil {
 		if err, ok := err.(*pq.Error); ok {
 			switch err.Code.Name() {
@@ -59,6 +95,11 @@ func (server *Server) followUser(context *gin.Context) {
 		return
 	}
 
+	server.hub.SendToUser(followedUserID, realtime.Event{
+		Type: realtime.EventNewFollower,
+		Data: follow,
+	})
+
 	context.JSON(http.StatusCreated, follow)
 }
 
@@ -86,7 +127,27 @@ func (server *Server) unfollowUser(context *gin.Context) {
 		UserID:         authorizationPayload.UserID,
 	}
 
-	err = server.database.UnfollowUser(context, arg)
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		err := queries.UnfollowUser(context, arg)
+		if err != nil {
+			return err
+		}
+
+		return queries.RemoveAuthorFromTimeline(context, database.RemoveAuthorFromTimelineParams{
+			UserID:   arg.UserID,
+			AuthorID: arg.FollowedUserID,
+		})
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// unfollowing a private account that hasn't answered yet takes the request back
+	_, err = server.database.DeleteFollowRequest(context, database.DeleteFollowRequestParams{
+		UserID:          authorizationPayload.UserID,
+		RequestedUserID: followedUserID,
+	})
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -96,9 +157,10 @@ func (server *Server) unfollowUser(context *gin.Context) {
 }
 
 type GetFollowersRequest struct {
-	Page     int32  `form:"page_number" binding:"required,min=1"`
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
 	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
 	UserID   string `form:"user_id" binding:"required,uuid"`
+	Cursor   string `form:"cursor"`
 }
 
 func (server *Server) getFollowers(context *gin.Context) {
@@ -114,12 +176,27 @@ func (server *Server) getFollowers(context *gin.Context) {
 		return
 	}
 
+	if !server.checkUserVisibleById(context, userID) {
+		return
+	}
+
 	arg := database.GetFollowersParams{
 		FollowedUserID: userID,
-		Offset:         (req.Page - 1) * req.PageSize,
 		Limit:          req.PageSize,
 	}
 
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
 	followers, err := server.database.GetFollowers(context, arg)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -132,13 +209,20 @@ func (server *Server) getFollowers(context *gin.Context) {
 		res = append(res, user.MakeResponse())
 	}
 
-	context.JSON(http.StatusOK, res)
+	nextCursor := ""
+	if len(followers) == int(req.PageSize) {
+		last := followers[len(followers)-1]
+		nextCursor = util.Cursor{CreatedAt: last.FollowedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
 }
 
 type GetFollowingRequest struct {
-	Page     int32  `form:"page_number" binding:"required,min=1"`
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
 	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
 	UserID   string `form:"user_id" binding:"required,uuid"`
+	Cursor   string `form:"cursor"`
 }
 
 func (server *Server) getFollowing(context *gin.Context) {
@@ -154,12 +238,27 @@ func (server *Server) getFollowing(context *gin.Context) {
 		return
 	}
 
+	if !server.checkUserVisibleById(context, userID) {
+		return
+	}
+
 	arg := database.GetFollowingParams{
 		UserID: userID,
-		Offset: (req.Page - 1) * req.PageSize,
 		Limit:  req.PageSize,
 	}
 
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
 	following, err := server.database.GetFollowing(context, arg)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -172,7 +271,13 @@ func (server *Server) getFollowing(context *gin.Context) {
 		res = append(res, user.MakeResponse())
 	}
 
-	context.JSON(http.StatusOK, res)
+	nextCursor := ""
+	if len(following) == int(req.PageSize) {
+		last := following[len(following)-1]
+		nextCursor = util.Cursor{CreatedAt: last.FollowedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
 }
 
 type GetFollowersCountRequest struct {
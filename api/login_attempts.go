@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	errInvalidCredentials   = errors.New("invalid username or password")
+	errTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+)
+
+const (
+	auditActionLoginSucceeded   = "login_succeeded"
+	auditActionLoginFailed      = "login_failed"
+	auditActionLoginLocked      = "login_locked"
+	auditActionLoginMfaRequired = "login_mfa_required"
+	auditActionLoginMfaFailed   = "login_mfa_failed"
+)
+
+func usernameLockoutKey(username string) string {
+	return "username:" + strings.ToLower(username)
+}
+
+func ipLockoutKey(ip string) string {
+	return "ip:" + ip
+}
+
+// checkLoginLockout responds with 429 and returns false if either the username
+// or the client's ip is currently locked out
+func (server *Server) checkLoginLockout(context *gin.Context, username string) bool {
+	usernameRetryAfter, err := server.usernameLimiter.RetryAfter(context, usernameLockoutKey(username))
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	ipRetryAfter, err := server.ipLimiter.RetryAfter(context, ipLockoutKey(context.ClientIP()))
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	retryAfter := max(usernameRetryAfter, ipRetryAfter)
+	if retryAfter == 0 {
+		return true
+	}
+
+	server.auditLogin(context, auditActionLoginLocked, username, uuid.Nil)
+
+	context.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	context.JSON(http.StatusTooManyRequests, errorResponse(errTooManyLoginAttempts))
+	return false
+}
+
+// recordLoginFailure counts the failure against both the username and the ip
+func (server *Server) recordLoginFailure(context *gin.Context, username string) error {
+	err := server.usernameLimiter.Fail(context, usernameLockoutKey(username))
+	if err != nil {
+		return err
+	}
+
+	return server.ipLimiter.Fail(context, ipLockoutKey(context.ClientIP()))
+}
+
+// recordLoginSuccess only clears the username counter, otherwise an attacker
+// could reset their ip counter by logging into an account of their own
+func (server *Server) recordLoginSuccess(context *gin.Context, username string) error {
+	return server.usernameLimiter.Succeed(context, usernameLockoutKey(username))
+}
+
+// auditLogin never fails the request, a missing audit entry is not worth
+// locking people out of their accounts
+func (server *Server) auditLogin(context *gin.Context, action string, username string, userID uuid.UUID) {
+	err := server.database.CreateAuditLog(context, database.CreateAuditLogParams{
+		ID:        uuid.New(),
+		UserID:    uuid.NullUUID{UUID: userID, Valid: userID != uuid.Nil},
+		Action:    action,
+		Username:  username,
+		ClientIp:  context.ClientIP(),
+		UserAgent: context.Request.UserAgent(),
+	})
+	if err != nil {
+		fmt.Println("failed to write audit log:", err)
+	}
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/realtime"
+	"github.com/dqrk0jeste/letscube-backend/token"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type SendMessageRequest struct {
+	ToUserID string `json:"to_user_id" binding:"required,uuid"`
+	Content  string `json:"content" binding:"required,max=1000"`
+}
+
+func (server *Server) sendMessage(context *gin.Context) {
+	var req SendMessageRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	toUserID, err := uuid.Parse(req.ToUserID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+
+	if authorizationPayload.UserID == toUserID {
+		context.Status(http.StatusBadRequest)
+		return
+	}
+
+	if !server.checkNotBlocked(context, authorizationPayload.UserID, toUserID) {
+		return
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := database.SendMessageParams{
+		ID:         id,
+		Content:    req.Content,
+		FromUserID: authorizationPayload.UserID,
+		ToUserID:   toUserID,
+	}
+
+	message, err := server.database.SendMessage(context, arg)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code.Name() == "foreign_key_violation" {
+				context.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := message.MakeResponse()
+
+	server.hub.SendToUser(toUserID, realtime.Event{
+		Type: realtime.EventNewMessage,
+		Data: res,
+	})
+
+	context.JSON(http.StatusCreated, res)
+}
+
+type GetConversationsRequest struct {
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
+}
+
+func (server *Server) getConversations(context *gin.Context) {
+	var req GetConversationsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+
+	arg := database.GetConversationsParams{
+		UserID: authorizationPayload.UserID,
+		Limit:  req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	conversations, err := server.database.GetConversations(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.ConversationResponse, 0)
+
+	for _, conversation := range conversations {
+		res = append(res, conversation.MakeResponse())
+	}
+
+	nextCursor := ""
+	if len(conversations) == int(req.PageSize) {
+		last := conversations[len(conversations)-1]
+		nextCursor = util.Cursor{CreatedAt: last.MessageCreatedAt, ID: last.MessageID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
+}
+
+type GetConversationRequest struct {
+	UserID   string `form:"user_id" binding:"required,uuid"`
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=50"`
+	Cursor   string `form:"cursor"`
+}
+
+// fetching the conversation also marks every message the peer has sent us as read
+func (server *Server) getConversation(context *gin.Context) {
+	var req GetConversationRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	peerID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+
+	arg := database.GetConversationParams{
+		UserID: authorizationPayload.UserID,
+		PeerID: peerID,
+		Limit:  req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	messages, err := server.database.GetConversation(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.database.MarkConversationAsRead(context, database.MarkConversationAsReadParams{
+		PeerID: peerID,
+		UserID: authorizationPayload.UserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.MessageResponse, 0)
+
+	for _, message := range messages {
+		res = append(res, message.MakeResponse())
+	}
+
+	nextCursor := ""
+	if len(messages) == int(req.PageSize) {
+		last := messages[len(messages)-1]
+		nextCursor = util.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
+}
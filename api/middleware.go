@@ -9,21 +9,60 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/token"
+	"github.com/dqrk0jeste/letscube-backend/util"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 const (
 	authorizationHeaderKey  = "authorization"
 	authorizationTypeBearer = "bearer"
 	authorizationPayloadKey = "authorization_payload"
+	authorizationUserKey    = "authorization_user"
+)
+
+var (
+	errTokenRevoked   = errors.New("token has been revoked")
+	errNotAccessToken = errors.New("token is not an access token")
 )
 
 func (server *Server) authMiddleware(context *gin.Context) {
+	server.authenticate(context, "")
+}
+
+// scopedAuthMiddleware works like authMiddleware, but also lets through personal
+// access tokens that have the scope. routes behind plain authMiddleware stay
+// reserved for tokens from a real login.
+func (server *Server) scopedAuthMiddleware(scope string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		server.authenticate(context, scope)
+	}
+}
+
+// optionalAuthMiddleware lets requests without a token through as anonymous,
+// while the ones that do carry a token are checked like in scopedAuthMiddleware.
+// it is meant for public routes that show more to some users, like posts of
+// private accounts to their followers
+func (server *Server) optionalAuthMiddleware(scope string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		if len(context.GetHeader(authorizationHeaderKey)) == 0 {
+			context.Next()
+			return
+		}
+		server.authenticate(context, scope)
+	}
+}
+
+func (server *Server) authenticate(context *gin.Context, scope string) {
 	authorizationHeader := context.GetHeader(authorizationHeaderKey)
 
 	if len(authorizationHeader) == 0 {
@@ -47,12 +86,94 @@ func (server *Server) authMiddleware(context *gin.Context) {
 	}
 
 	token := fields[1]
+
+	if strings.HasPrefix(token, personalAccessTokenPrefix) {
+		server.authenticatePersonalAccessToken(context, token, scope)
+		return
+	}
+
 	payload, err := server.tokenMaker.VerifyToken(token)
 	if err != nil {
 		context.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
+	user, err := server.getAuthorizedUser(context, payload)
+	if err != nil {
+		if err == sql.ErrNoRows || err == errTokenRevoked || err == errNotAccessToken {
+			context.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		context.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	context.Set(authorizationPayloadKey, payload)
+	context.Set(authorizationUserKey, user)
 	context.Next()
 }
+
+// getAuthorizedUser loads the owner of a valid access token and makes sure the
+// token wasn't issued before the last password change. the two timestamps come
+// from different clocks (ours and the database's), so they are compared with
+// second precision. tokens of accounts pending deletion are treated as revoked
+// too, logging in again is the only way back. refresh tokens are turned away,
+// they are only good for their session and stop working when it is revoked.
+func (server *Server) getAuthorizedUser(context *gin.Context, payload *token.Payload) (database.User, error) {
+	if payload.Type != token.TokenTypeAccess {
+		return database.User{}, errNotAccessToken
+	}
+
+	user, err := server.database.GetUserById(context, payload.UserID)
+	if err != nil {
+		return database.User{}, err
+	}
+
+	if user.DeletedAt.Valid {
+		return database.User{}, errTokenRevoked
+	}
+
+	if payload.IssuedAt.Before(user.PasswordChangedAt.Truncate(time.Second)) {
+		return database.User{}, errTokenRevoked
+	}
+
+	return user, nil
+}
+
+// verifiedUserMiddleware has to come after authMiddleware. unverified users can
+// still log in and browse, but can't create any content.
+func (server *Server) verifiedUserMiddleware(context *gin.Context) {
+	user := context.MustGet(authorizationUserKey).(database.User)
+
+	if !user.IsVerified {
+		err := errors.New("email is not verified")
+		context.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	context.Next()
+}
+
+// roleMiddleware has to come after authMiddleware. the role is read from the
+// user rather than the token, so a demotion takes effect right away instead of
+// when the access token expires.
+func (server *Server) roleMiddleware(required string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		user := context.MustGet(authorizationUserKey).(database.User)
+
+		if !util.HasRole(user.Role, required) {
+			err := fmt.Errorf("%s role is required", required)
+			context.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+
+		context.Next()
+	}
+}
+
+// canModerate reports whether the authorized user may remove content owned by
+// ownerID, which is the owner themselves and any moderator
+func canModerate(context *gin.Context, ownerID uuid.UUID) bool {
+	user := context.MustGet(authorizationUserKey).(database.User)
+	return user.ID == ownerID || util.HasRole(user.Role, util.RoleModerator)
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type MuteUserUri struct {
+	UserID string `uri:"id" binding:"required,uuid"`
+}
+
+// muting only hides the muted user's posts from the feed of the caller, the
+// muted user is never told and can still follow, message and comment
+func (server *Server) muteUser(context *gin.Context) {
+	var uri MuteUserUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	mutedUserID, err := uuid.Parse(uri.UserID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	if authorizedUser.ID == mutedUserID {
+		context.Status(http.StatusBadRequest)
+		return
+	}
+
+	mute, err := server.database.MuteUser(context, database.MuteUserParams{
+		UserID:      authorizedUser.ID,
+		MutedUserID: mutedUserID,
+	})
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			switch err.Code.Name() {
+			case "unique_violation":
+				context.JSON(http.StatusConflict, errorResponse(err))
+				return
+			case "foreign_key_violation":
+				context.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusCreated, mute)
+}
+
+func (server *Server) unmuteUser(context *gin.Context) {
+	var uri MuteUserUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	mutedUserID, err := uuid.Parse(uri.UserID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	rowsAffected, err := server.database.UnmuteUser(context, database.UnmuteUserParams{
+		UserID:      authorizedUser.ID,
+		MutedUserID: mutedUserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rowsAffected == 0 {
+		context.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	context.Status(http.StatusOK)
+}
+
+type GetMutedUsersRequest struct {
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
+}
+
+func (server *Server) getMutedUsers(context *gin.Context) {
+	var req GetMutedUsersRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	arg := database.GetMutedUsersParams{
+		UserID: authorizedUser.ID,
+		Limit:  req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	users, err := server.database.GetMutedUsers(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.UserResponse, 0)
+
+	for _, user := range users {
+		res = append(res, user.MakeResponse())
+	}
+
+	nextCursor := ""
+	if len(users) == int(req.PageSize) {
+		last := users[len(users)-1]
+		nextCursor = util.Cursor{CreatedAt: last.MutedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
+}
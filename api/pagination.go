@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pageResponse is the envelope cursor paginated lists are sent in. next_cursor
+// is passed back as the cursor query parameter to get the following page, it
+// is empty once there is nothing left
+type pageResponse struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"next_cursor"`
+}
+
+// sendPage sends one page of a list. clients still on the deprecated
+// page_number paging get the bare list they always did, everyone else gets the
+// envelope
+func sendPage(context *gin.Context, page int32, items interface{}, nextCursor string) {
+	if page > 0 {
+		context.Header("Deprecation", "true")
+		context.JSON(http.StatusOK, items)
+		return
+	}
+
+	context.JSON(http.StatusOK, pageResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+	})
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var errInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// always responds with 200, so it can't be used to find out which emails have an account
+func (server *Server) forgotPassword(context *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.database.GetUserByEmail(context, req.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.Status(http.StatusOK)
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resetToken, err := util.GenerateRandomToken(32)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.database.InvalidatePasswordResetTokens(context, user.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = server.database.CreatePasswordResetToken(context, database.CreatePasswordResetTokenParams{
+		ID:        id,
+		UserID:    user.ID,
+		TokenHash: util.HashToken(resetToken),
+		ExpiresAt: time.Now().Add(server.config.PasswordResetTokenDuration),
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nsomeone asked to reset the password of your account. If it was you, open the link below:\n\n%s/reset-password?token=%s\n\nThe link expires in %s. If you didn't ask for this, you can ignore this email.",
+		user.Username,
+		server.config.AppURL,
+		resetToken,
+		server.config.PasswordResetTokenDuration,
+	)
+
+	// sending can only fail for emails that have an account, so a failure is
+	// logged instead of sent back. the user can ask for a new email
+	err = server.mailSender.Send(user.Email, "Reset your password", body)
+	if err != nil {
+		fmt.Println("there has been an error sending password reset email to user " + user.ID.String())
+	}
+
+	context.Status(http.StatusOK)
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,printascii"`
+}
+
+func (server *Server) resetPassword(context *gin.Context) {
+	var req ResetPasswordRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	resetToken, err := server.database.GetPasswordResetTokenByHash(context, util.HashToken(req.Token))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusBadRequest, errorResponse(errInvalidPasswordResetToken))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if resetToken.UsedAt.Valid || time.Now().After(resetToken.ExpiresAt) {
+		context.JSON(http.StatusBadRequest, errorResponse(errInvalidPasswordResetToken))
+		return
+	}
+
+	user, err := server.database.GetUserById(context, resetToken.UserID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err := server.passwordPolicy.Validate(req.Password, user.Username); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	passwordHash, err := util.GeneratePasswordHash(req.Password)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		rowsAffected, err := queries.UsePasswordResetToken(context, resetToken.ID)
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return errInvalidPasswordResetToken
+		}
+
+		user, err = queries.UpdateUsersPassword(context, database.UpdateUsersPasswordParams{
+			ID:           resetToken.UserID,
+			PasswordHash: passwordHash,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = queries.RevokeUserPersonalAccessTokens(context, resetToken.UserID)
+		if err != nil {
+			return err
+		}
+
+		return queries.BlockUserSessions(context, resetToken.UserID)
+	})
+	if err != nil {
+		if err == errInvalidPasswordResetToken {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, user.MakeResponse())
+}
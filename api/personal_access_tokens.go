@@ -0,0 +1,240 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/token"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// personal access tokens start with this prefix, which is how authMiddleware
+// tells them apart from access tokens
+const personalAccessTokenPrefix = "lcp_"
+
+// how much of the token is kept in plain text, so users can tell their tokens apart
+const personalAccessTokenVisibleLength = len(personalAccessTokenPrefix) + 8
+
+const (
+	scopePostsRead      = "posts:read"
+	scopePostsWrite     = "posts:write"
+	scopeCommentsWrite  = "comments:write"
+	scopeReactionsWrite = "reactions:write"
+	scopeFollowsWrite   = "follows:write"
+	scopeUsersRead      = "users:read"
+	scopeMessagesRead   = "messages:read"
+	scopeMessagesWrite  = "messages:write"
+)
+
+var personalAccessTokenScopes = []string{
+	scopePostsRead,
+	scopePostsWrite,
+	scopeCommentsWrite,
+	scopeReactionsWrite,
+	scopeFollowsWrite,
+	scopeUsersRead,
+	scopeMessagesRead,
+	scopeMessagesWrite,
+}
+
+var (
+	errInvalidPersonalAccessToken    = errors.New("invalid personal access token")
+	errPersonalAccessTokenNotAllowed = errors.New("personal access tokens can't be used here")
+)
+
+func (server *Server) authenticatePersonalAccessToken(context *gin.Context, accessToken string, scope string) {
+	if scope == "" {
+		context.AbortWithStatusJSON(http.StatusForbidden, errorResponse(errPersonalAccessTokenNotAllowed))
+		return
+	}
+
+	personalAccessToken, err := server.database.GetPersonalAccessTokenByHash(context, util.HashToken(accessToken))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errInvalidPersonalAccessToken))
+			return
+		}
+		context.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	expired := personalAccessToken.ExpiresAt.Valid && time.Now().After(personalAccessToken.ExpiresAt.Time)
+	if personalAccessToken.RevokedAt.Valid || expired {
+		context.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errInvalidPersonalAccessToken))
+		return
+	}
+
+	if !slices.Contains(personalAccessToken.Scopes, scope) {
+		err := fmt.Errorf("token is missing the %s scope", scope)
+		context.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	user, err := server.database.GetUserById(context, personalAccessToken.UserID)
+	if err != nil {
+		context.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if user.DeletedAt.Valid {
+		context.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errInvalidPersonalAccessToken))
+		return
+	}
+
+	// a stale last used timestamp is not worth failing the request over
+	err = server.database.TouchPersonalAccessToken(context, personalAccessToken.ID)
+	if err != nil {
+		fmt.Println("failed to update personal access token last use:", err)
+	}
+
+	// handlers only care about the payload, so one is made up for the token
+	payload := &token.Payload{
+		ID:        personalAccessToken.ID,
+		UserID:    user.ID,
+		Role:      user.Role,
+		IssuedAt:  personalAccessToken.CreatedAt,
+		ExpiredAt: personalAccessToken.ExpiresAt.Time,
+	}
+
+	context.Set(authorizationPayloadKey, payload)
+	context.Set(authorizationUserKey, user)
+	context.Next()
+}
+
+// tokens don't expire unless asked to and outlive password changes until they
+// are revoked, so creating one asks for the password, an access token alone is
+// not enough
+type CreatePersonalAccessTokenRequest struct {
+	Password      string   `json:"password" binding:"required,printascii"`
+	Name          string   `json:"name" binding:"required,max=64"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=365"`
+}
+
+type createPersonalAccessTokenResponse struct {
+	Token               string                               `json:"token"`
+	PersonalAccessToken database.PersonalAccessTokenResponse `json:"personal_access_token"`
+}
+
+func (server *Server) createPersonalAccessToken(context *gin.Context) {
+	var req CreatePersonalAccessTokenRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	err := util.VerifyPassword(req.Password, authorizedUser.PasswordHash)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	for _, scope := range req.Scopes {
+		if !slices.Contains(personalAccessTokenScopes, scope) {
+			err := fmt.Errorf("unknown scope %s", scope)
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+
+	var expiresAt sql.NullTime
+	if req.ExpiresInDays != 0 {
+		expiresAt = sql.NullTime{
+			Time:  time.Now().AddDate(0, 0, req.ExpiresInDays),
+			Valid: true,
+		}
+	}
+
+	randomToken, err := util.GenerateRandomToken(32)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	accessToken := personalAccessTokenPrefix + randomToken
+
+	personalAccessToken, err := server.database.CreatePersonalAccessToken(context, database.CreatePersonalAccessTokenParams{
+		ID:          uuid.New(),
+		UserID:      authorizedUser.ID,
+		Name:        req.Name,
+		TokenHash:   util.HashToken(accessToken),
+		TokenPrefix: accessToken[:personalAccessTokenVisibleLength],
+		Scopes:      scopes,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// this is the only time the token is ever shown
+	context.JSON(http.StatusOK, createPersonalAccessTokenResponse{
+		Token:               accessToken,
+		PersonalAccessToken: personalAccessToken.MakeResponse(),
+	})
+}
+
+func (server *Server) getPersonalAccessTokens(context *gin.Context) {
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	personalAccessTokens, err := server.database.GetPersonalAccessTokensByUser(context, authorizedUser.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.PersonalAccessTokenResponse, 0)
+	for _, personalAccessToken := range personalAccessTokens {
+		res = append(res, personalAccessToken.MakeResponse())
+	}
+
+	context.JSON(http.StatusOK, res)
+}
+
+type RevokePersonalAccessTokenRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+func (server *Server) revokePersonalAccessToken(context *gin.Context) {
+	var req RevokePersonalAccessTokenRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	rowsAffected, err := server.database.RevokePersonalAccessToken(context, database.RevokePersonalAccessTokenParams{
+		ID:     id,
+		UserID: authorizedUser.ID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rowsAffected == 0 {
+		context.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	context.Status(http.StatusOK)
+}
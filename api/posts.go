@@ -1,16 +1,21 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
 	"mime/multipart"
 	"net/http"
 	"slices"
+	"sort"
 	"strconv"
 	"sync"
 
 	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/realtime"
 	"github.com/dqrk0jeste/letscube-backend/token"
+	"github.com/dqrk0jeste/letscube-backend/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -50,49 +55,95 @@ func (server *Server) createPost(context *gin.Context) {
 		}
 	}
 
-	ch := make(chan error)
+	imageKeys, errorProcessingImagesCounter := server.uploadPostImages(context, files, func(index int) string {
+		return postImageKey(id, index)
+	})
+
+	arg := database.CreatePostParams{
+		ID:          id,
+		TextContent: req.TextContent,
+		ImageCount:  int32(len(imageKeys)),
+		ImageKeys:   imageKeys,
+		UserID:      authorizationPayload.UserID,
+	}
+
+	var post database.Post
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		var err error
+		post, err = queries.CreatePost(context, arg)
+		if err != nil {
+			return err
+		}
+
+		return server.fanOutPost(context, queries, post)
+	})
+	if err != nil {
+		server.deletePostImages(imageKeys)
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusCreated, gin.H{
+		"post":             post,
+		"number_of_errors": errorProcessingImagesCounter,
+	})
+}
+
+// postImageKey is the name an image of a new post is stored under in the
+// bucket. images added by edits get postRevisionImageKey instead, posts keep
+// the list of their keys in image_keys
+func postImageKey(postID uuid.UUID, index int) string {
+	return postID.String() + "_" + strconv.Itoa(index) + ".jpg"
+}
+
+// postRevisionImageKey is where an edit stores the images it adds, under the id
+// of the revision the edit creates, so they never overwrite what older
+// revisions point to
+func postRevisionImageKey(postID uuid.UUID, revisionID uuid.UUID, index int) string {
+	return postID.String() + "_" + revisionID.String() + "_" + strconv.Itoa(index) + ".jpg"
+}
+
+// uploadPostImages uploads the images at the same time and returns the keys of
+// the ones that made it, in the order of the files, and how many failed
+func (server *Server) uploadPostImages(context *gin.Context, files []*multipart.FileHeader, key func(index int) string) ([]string, int) {
+	errs := make([]error, len(files))
 	wg := sync.WaitGroup{}
-	errorProcessingImagesCounter := 0
 
 	for index, image := range files {
 		wg.Add(1)
 		go func(image *multipart.FileHeader, index int) {
 			defer wg.Done()
-			imageNameToSave := id.String() + "_" + strconv.Itoa(index) + ".jpg"
-
-			_, err := server.s3Controller.Upload(context, image, imageNameToSave)
-			ch <- err
+			_, errs[index] = server.s3Controller.Upload(context, image, key(index))
 		}(image, index)
 	}
 
-	go func() {
-		defer close(ch)
-		wg.Wait()
-	}()
+	wg.Wait()
 
-	for err := range ch {
+	imageKeys := make([]string, 0, len(files))
+	errorProcessingImagesCounter := 0
+
+	for index, err := range errs {
 		if err != nil {
 			errorProcessingImagesCounter++
+			continue
 		}
+		imageKeys = append(imageKeys, key(index))
 	}
 
-	arg := database.CreatePostParams{
-		ID:          id,
-		TextContent: req.TextContent,
-		ImageCount:  int32(len(files)),
-		UserID:      authorizationPayload.UserID,
-	}
+	return imageKeys, errorProcessingImagesCounter
+}
 
-	post, err := server.database.CreatePost(context, arg)
-	if err != nil {
-		context.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+// deletePostImages deletes in the background, a failed delete only leaves an
+// object nothing points to
+func (server *Server) deletePostImages(imageKeys []string) {
+	for _, key := range imageKeys {
+		go func(key string) {
+			err := server.s3Controller.Delete(context.Background(), key)
+			if err != nil {
+				fmt.Println("there has been an error deleting image " + key)
+			}
+		}(key)
 	}
-
-	context.JSON(http.StatusCreated, gin.H{
-		"post":             post,
-		"number_of_errors": errorProcessingImagesCounter,
-	})
 }
 
 type DeletePostRequest struct {
@@ -106,8 +157,6 @@ func (server *Server) deletePost(context *gin.Context) {
 		return
 	}
 
-	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
-
 	id, err := uuid.Parse(req.ID)
 	if err != nil {
 		context.JSON(http.StatusBadRequest, errorResponse(err))
@@ -124,26 +173,26 @@ func (server *Server) deletePost(context *gin.Context) {
 		return
 	}
 
-	if post.UserID != authorizationPayload.UserID {
+	if !canModerate(context, post.UserID) {
 		context.Status(http.StatusForbidden)
 		return
 	}
 
-	err = server.database.DeletePost(context, id)
+	// the revisions go with the post, so their images have to be looked up first
+	imageKeys, err := server.database.GetPostImageKeys(context, id)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	for i := 0; i < int(post.ImageCount); i++ {
-		go func(i int) {
-			err := server.s3Controller.Delete(context, id.String()+"_"+strconv.Itoa(i)+".jpg")
-			if err != nil {
-				fmt.Println("there has been an error deleting image" + id.String() + "_" + strconv.Itoa(i) + ".jpg")
-			}
-		}(i)
+	err = server.database.DeletePost(context, id)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
+	server.deletePostImages(imageKeys)
+
 	context.Status(http.StatusOK)
 }
 
@@ -151,7 +200,6 @@ type GetPostByIdRequest struct {
 	ID string `uri:"id" binding:"required,uuid"`
 }
 
-// in the future, we will have public and private accounts so we will have to check if user that requested the post follows the user whose post it is
 func (server *Server) getPostById(context *gin.Context) {
 	var req GetPostByIdRequest
 	if err := context.ShouldBindUri(&req); err != nil {
@@ -165,23 +213,26 @@ func (server *Server) getPostById(context *gin.Context) {
 		return
 	}
 
-	post, err := server.database.GetPostById(context, id)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			context.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
+	post, ok := server.loadVisiblePost(context, id)
+	if !ok {
+		return
+	}
+
+	res := []database.PostResponse{post.MakeResponse()}
+
+	if err := server.addPostReactions(context, res); err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	context.JSON(http.StatusOK, post.MakeResponse())
+	context.JSON(http.StatusOK, res[0])
 }
 
 type GetPostsByUserRequest struct {
 	UserID   string `form:"user_id" binding:"required,uuid"`
-	Page     int32  `form:"page_number" binding:"required"`
-	PageSize int32  `form:"page_size" binding:"required,max=20"`
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
 }
 
 func (server *Server) getPostsByUser(context *gin.Context) {
@@ -197,12 +248,27 @@ func (server *Server) getPostsByUser(context *gin.Context) {
 		return
 	}
 
+	if !server.checkUserVisibleById(context, id) {
+		return
+	}
+
 	arg := database.GetPostsByUserParams{
 		UserID: id,
-		Offset: (req.Page - 1) * req.PageSize,
 		Limit:  req.PageSize,
 	}
 
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
 	posts, err := server.database.GetPostsByUser(context, arg)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -215,14 +281,30 @@ func (server *Server) getPostsByUser(context *gin.Context) {
 		res = append(res, post.MakeResponse())
 	}
 
-	context.JSON(http.StatusOK, res)
+	if err := server.addPostReactions(context, res); err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	nextCursor := ""
+	if len(posts) == int(req.PageSize) {
+		last := posts[len(posts)-1]
+		nextCursor = util.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
 }
 
 type GetFeedRequest struct {
-	Page     int32 `form:"page_number" binding:"required"`
-	PageSize int32 `form:"page_size" binding:"required,max=20"`
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
 }
 
+// getFeed reads the caller's timeline, which createPost fills through
+// fanOutPost, and merges in the latest posts of the followed pull authors,
+// which are never fanned out. deleted posts leave the timelines with them, the
+// foreign key cascades
 func (server *Server) getFeed(context *gin.Context) {
 	var req GetFeedRequest
 	if err := context.ShouldBindQuery(&req); err != nil {
@@ -232,10 +314,34 @@ func (server *Server) getFeed(context *gin.Context) {
 
 	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
 
+	// both sources are read from the start up to the requested page when
+	// page_number is used, the merged list is only cut afterwards
+	offset := 0
+	limit := req.PageSize
+	if req.Page > 0 {
+		offset = int((req.Page - 1) * req.PageSize)
+		limit = req.Page * req.PageSize
+	}
+
 	arg := database.GetFeedParams{
 		UserID: authorizationPayload.UserID,
-		Offset: (req.Page - 1) * req.PageSize,
-		Limit:  req.PageSize,
+		Limit:  limit,
+	}
+	pulledArg := database.GetPulledFeedPostsParams{
+		UserID: authorizationPayload.UserID,
+		Limit:  limit,
+	}
+
+	if req.Page == 0 && req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+		pulledArg.CursorCreatedAt = arg.CursorCreatedAt
+		pulledArg.CursorID = arg.CursorID
 	}
 
 	posts, err := server.database.GetFeed(context, arg)
@@ -244,13 +350,51 @@ func (server *Server) getFeed(context *gin.Context) {
 		return
 	}
 
-	res := make([]database.PostResponse, 0)
+	pulledPosts, err := server.database.GetPulledFeedPosts(context, pulledArg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	feed := make([]database.PostResponse, 0, len(posts)+len(pulledPosts))
+	seen := make(map[uuid.UUID]bool, len(posts))
 
 	for _, post := range posts {
-		res = append(res, post.MakeResponse())
+		seen[post.ID] = true
+		feed = append(feed, post.MakeResponse())
+	}
+
+	// an author who became a pull author keeps the posts already fanned out
+	for _, post := range pulledPosts {
+		if !seen[post.ID] {
+			feed = append(feed, post.MakeResponse())
+		}
+	}
+
+	sort.SliceStable(feed, func(i, j int) bool {
+		if !feed[i].CreatedAt.Equal(feed[j].CreatedAt) {
+			return feed[i].CreatedAt.After(feed[j].CreatedAt)
+		}
+		return bytes.Compare(feed[i].ID[:], feed[j].ID[:]) > 0
+	})
+
+	res := make([]database.PostResponse, 0)
+	if offset < len(feed) {
+		res = append(res, feed[offset:min(offset+int(req.PageSize), len(feed))]...)
+	}
+
+	if err := server.addPostReactions(context, res); err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	nextCursor := ""
+	if len(res) == int(req.PageSize) {
+		last := res[len(res)-1]
+		nextCursor = util.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
 	}
 
-	context.JSON(http.StatusOK, res)
+	sendPage(context, req.Page, res, nextCursor)
 }
 
 func (server *Server) getGuestFeed(context *gin.Context) {
@@ -261,8 +405,19 @@ func (server *Server) getGuestFeed(context *gin.Context) {
 	}
 
 	arg := database.GetGuestFeedParams{
-		Offset: (req.Page - 1) * req.PageSize,
-		Limit:  req.PageSize,
+		Limit: req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
 	}
 
 	posts, err := server.database.GetGuestFeed(context, arg)
@@ -277,7 +432,18 @@ func (server *Server) getGuestFeed(context *gin.Context) {
 		res = append(res, post.MakeResponse())
 	}
 
-	context.JSON(http.StatusOK, res)
+	if err := server.addPostReactions(context, res); err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	nextCursor := ""
+	if len(posts) == int(req.PageSize) {
+		last := posts[len(posts)-1]
+		nextCursor = util.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
 }
 
 type PostCommentRequest struct {
@@ -301,6 +467,15 @@ func (server *Server) postComment(context *gin.Context) {
 	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
 	userID := authorizationPayload.UserID
 
+	post, ok := server.loadVisiblePost(context, postID)
+	if !ok {
+		return
+	}
+
+	if !server.checkNotBlocked(context, userID, post.UserID) {
+		return
+	}
+
 	id, err := uuid.NewRandom()
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -326,6 +501,13 @@ func (server *Server) postComment(context *gin.Context) {
 		return
 	}
 
+	if post.UserID != userID {
+		server.hub.SendToUser(post.UserID, realtime.Event{
+			Type: realtime.EventNewComment,
+			Data: comment,
+		})
+	}
+
 	context.JSON(http.StatusCreated, comment)
 }
 
@@ -346,9 +528,6 @@ func (server *Server) deleteComment(context *gin.Context) {
 		return
 	}
 
-	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
-	userID := authorizationPayload.UserID
-
 	comment, err := server.database.GetCommentById(context, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -359,7 +538,7 @@ func (server *Server) deleteComment(context *gin.Context) {
 		return
 	}
 
-	if userID != comment.UserID {
+	if !canModerate(context, comment.UserID) {
 		context.Status(http.StatusForbidden)
 		return
 	}
@@ -375,8 +554,9 @@ func (server *Server) deleteComment(context *gin.Context) {
 
 type GetCommentsRequest struct {
 	PostID   string `form:"post_id" binding:"required,uuid"`
-	Page     int32  `form:"page_number" binding:"required,min=1"`
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
 	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
 }
 
 func (server *Server) getComments(context *gin.Context) {
@@ -392,15 +572,34 @@ func (server *Server) getComments(context *gin.Context) {
 		return
 	}
 
+	if _, ok := server.loadVisiblePost(context, postID); !ok {
+		return
+	}
+
 	arg := database.GetCommentsByPostParams{
 		PostID: postID,
 		Limit:  req.PageSize,
-		Offset: (req.Page - 1) * req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		// comments are sorted by their replies first, one that gets a reply while
+		// the client is paging can move above the cursor and be skipped
+		arg.CursorNumberOfReplies = sql.NullInt64{Int64: cursor.Rank, Valid: true}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
 	}
 
 	comments, err := server.database.GetCommentsByPost(context, arg)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	res := make([]database.CommentResponse, 0)
@@ -409,7 +608,18 @@ func (server *Server) getComments(context *gin.Context) {
 		res = append(res, comment.MakeResponse())
 	}
 
-	context.JSON(http.StatusOK, res)
+	if err := server.addCommentReactions(context, res); err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	nextCursor := ""
+	if len(comments) == int(req.PageSize) {
+		last := comments[len(comments)-1]
+		nextCursor = util.Cursor{CreatedAt: last.CreatedAt, ID: last.ID, Rank: last.NumberOfReplies}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
 }
 
 type PostReplyRequest struct {
@@ -433,6 +643,15 @@ func (server *Server) postReply(context *gin.Context) {
 	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
 	userID := authorizationPayload.UserID
 
+	comment, post, ok := server.loadVisibleComment(context, postID)
+	if !ok {
+		return
+	}
+
+	if !server.checkNotBlocked(context, userID, comment.UserID) || !server.checkNotBlocked(context, userID, post.UserID) {
+		return
+	}
+
 	id, err := uuid.NewRandom()
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -478,9 +697,6 @@ func (server *Server) deleteReply(context *gin.Context) {
 		return
 	}
 
-	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
-	userID := authorizationPayload.UserID
-
 	reply, err := server.database.GetReplyById(context, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -491,7 +707,7 @@ func (server *Server) deleteReply(context *gin.Context) {
 		return
 	}
 
-	if userID != reply.UserID {
+	if !canModerate(context, reply.UserID) {
 		context.Status(http.StatusForbidden)
 		return
 	}
@@ -507,8 +723,9 @@ func (server *Server) deleteReply(context *gin.Context) {
 
 type GetRepliesRequest struct {
 	CommentID string `form:"comment_id" binding:"required,uuid"`
-	Page      int32  `form:"page_number" binding:"required,min=1"`
+	Page      int32  `form:"page_number" binding:"omitempty,min=1"`
 	PageSize  int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor    string `form:"cursor"`
 }
 
 func (server *Server) getReplies(context *gin.Context) {
@@ -524,15 +741,31 @@ func (server *Server) getReplies(context *gin.Context) {
 		return
 	}
 
+	if _, _, ok := server.loadVisibleComment(context, commentID); !ok {
+		return
+	}
+
 	arg := database.GetRepliesByCommentParams{
 		CommentID: commentID,
 		Limit:     req.PageSize,
-		Offset:    (req.Page - 1) * req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
 	}
 
 	replies, err := server.database.GetRepliesByComment(context, arg)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	res := make([]database.ReplyResponse, 0)
@@ -541,5 +774,16 @@ func (server *Server) getReplies(context *gin.Context) {
 		res = append(res, reply.MakeResponse())
 	}
 
-	context.JSON(http.StatusOK, res)
+	if err := server.addReplyReactions(context, res); err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	nextCursor := ""
+	if len(replies) == int(req.PageSize) {
+		last := replies[len(replies)-1]
+		nextCursor = util.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
 }
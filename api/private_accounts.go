@@ -0,0 +1,184 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var errPrivateAccount = errors.New("this account is private")
+
+// canViewUser reports whether the caller may see the posts, comments and follow
+// lists of the owner. public accounts are open to everyone, private ones only to
+// the owner, their followers and moderators. anonymous callers only get here
+// through optionalAuthMiddleware, so the user may be missing from the context
+func (server *Server) canViewUser(context *gin.Context, ownerID uuid.UUID, isPrivate bool) (bool, error) {
+	if !isPrivate {
+		return true, nil
+	}
+
+	value, ok := context.Get(authorizationUserKey)
+	if !ok {
+		return false, nil
+	}
+
+	viewer := value.(database.User)
+	if viewer.ID == ownerID || util.HasRole(viewer.Role, util.RoleModerator) {
+		return true, nil
+	}
+
+	return server.database.IsFollowing(context, database.IsFollowingParams{
+		UserID:         viewer.ID,
+		FollowedUserID: ownerID,
+	})
+}
+
+// checkUserVisible sends the error response itself, the handler should only go
+// on if it returns true
+func (server *Server) checkUserVisible(context *gin.Context, ownerID uuid.UUID, isPrivate bool) bool {
+	ok, err := server.canViewUser(context, ownerID, isPrivate)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if !ok {
+		context.JSON(http.StatusForbidden, errorResponse(errPrivateAccount))
+		return false
+	}
+
+	return true
+}
+
+// checkUserVisibleById works like checkUserVisible for handlers that only have
+// the id of the owner. deleted accounts are reported as not found
+func (server *Server) checkUserVisibleById(context *gin.Context, ownerID uuid.UUID) bool {
+	owner, err := server.database.GetUserById(context, ownerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if owner.DeletedAt.Valid {
+		context.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return false
+	}
+
+	return server.checkUserVisible(context, owner.ID, owner.IsPrivate)
+}
+
+// loadVisiblePost loads a post and checks that the caller may see it, sending
+// the error response itself if not. posts of deleted accounts are reported as
+// not found
+func (server *Server) loadVisiblePost(context *gin.Context, postID uuid.UUID) (database.GetPostByIdRow, bool) {
+	post, err := server.database.GetPostById(context, postID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return post, false
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return post, false
+	}
+
+	if post.DeletedAt.Valid {
+		context.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return post, false
+	}
+
+	return post, server.checkUserVisible(context, post.UserID, post.IsPrivate)
+}
+
+// loadVisibleComment loads a comment together with the post it belongs to.
+// comments and replies are only as visible as the post they are under
+func (server *Server) loadVisibleComment(context *gin.Context, commentID uuid.UUID) (database.GetCommentByIdRow, database.GetPostByIdRow, bool) {
+	comment, err := server.database.GetCommentById(context, commentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return comment, database.GetPostByIdRow{}, false
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return comment, database.GetPostByIdRow{}, false
+	}
+
+	post, ok := server.loadVisiblePost(context, comment.PostID)
+	return comment, post, ok
+}
+
+// loadVisibleReply loads a reply and checks the comment and post above it
+func (server *Server) loadVisibleReply(context *gin.Context, replyID uuid.UUID) (database.GetReplyByIdRow, bool) {
+	reply, err := server.database.GetReplyById(context, replyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return reply, false
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return reply, false
+	}
+
+	_, _, ok := server.loadVisibleComment(context, reply.CommentID)
+	return reply, ok
+}
+
+type UpdatePrivacyRequest struct {
+	IsPrivate *bool `json:"is_private" binding:"required"`
+}
+
+// going public lets everyone who is still waiting in, since there is nothing
+// left for the requests to wait for
+func (server *Server) updatePrivacy(context *gin.Context) {
+	var req UpdatePrivacyRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	var user database.User
+	err := server.database.ExecTx(context, func(queries *database.Queries) error {
+		var err error
+		user, err = queries.UpdateUsersPrivacy(context, database.UpdateUsersPrivacyParams{
+			IsPrivate: *req.IsPrivate,
+			ID:        authorizedUser.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if user.IsPrivate {
+			return nil
+		}
+
+		err = queries.ApproveAllFollowRequests(context, user.ID)
+		if err != nil {
+			return err
+		}
+
+		// followers who were already there have the posts in their timelines,
+		// the backfill only adds what the approved ones are missing
+		err = backfillFollowersTimelines(context, queries, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return queries.DeleteFollowRequestsByUser(context, user.ID)
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, user.MakeResponse())
+}
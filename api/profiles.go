@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	errInvalidWcaID     = errors.New("wca id must look like 2009ZEMD01")
+	errInvalidMainEvent = errors.New("main event must be a wca event id")
+)
+
+// every field is optional, the ones left out keep their current value. sending
+// an empty string (or an empty list of links) clears the field
+type UpdateProfileRequest struct {
+	DisplayName *string   `json:"display_name" binding:"omitempty,max=50"`
+	Bio         *string   `json:"bio" binding:"omitempty,max=300"`
+	CountryCode *string   `json:"country_code" binding:"omitempty,len=0|iso3166_1_alpha2"`
+	MainEvent   *string   `json:"main_event"`
+	WcaID       *string   `json:"wca_id"`
+	SocialLinks *[]string `json:"social_links" binding:"omitempty,max=5,dive,http_url,max=200"`
+}
+
+func (server *Server) updateProfile(context *gin.Context) {
+	var req UpdateProfileRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.MainEvent != nil && *req.MainEvent != "" && !util.IsValidWcaEvent(*req.MainEvent) {
+		context.JSON(http.StatusBadRequest, errorResponse(errInvalidMainEvent))
+		return
+	}
+
+	if req.WcaID != nil && *req.WcaID != "" && !util.IsValidWcaID(*req.WcaID) {
+		context.JSON(http.StatusBadRequest, errorResponse(errInvalidWcaID))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	arg := database.UpdateUsersProfileParams{
+		ID:          authorizedUser.ID,
+		DisplayName: authorizedUser.DisplayName,
+		Bio:         authorizedUser.Bio,
+		CountryCode: authorizedUser.CountryCode,
+		MainEvent:   authorizedUser.MainEvent,
+		WcaID:       authorizedUser.WcaID,
+		SocialLinks: authorizedUser.SocialLinks,
+	}
+
+	if req.DisplayName != nil {
+		arg.DisplayName = *req.DisplayName
+	}
+	if req.Bio != nil {
+		arg.Bio = *req.Bio
+	}
+	if req.CountryCode != nil {
+		arg.CountryCode = *req.CountryCode
+	}
+	if req.MainEvent != nil {
+		arg.MainEvent = *req.MainEvent
+	}
+	if req.WcaID != nil {
+		arg.WcaID = *req.WcaID
+	}
+	if req.SocialLinks != nil {
+		arg.SocialLinks = *req.SocialLinks
+	}
+
+	user, err := server.database.UpdateUsersProfile(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, user.MakeResponse())
+}
+
+type UpdateAvatarRequest struct {
+	Avatar *multipart.FileHeader `form:"avatar" binding:"required"`
+}
+
+// avatarKey is the name a new avatar is stored under in the bucket. every
+// upload gets a new name, so clients and caches never show a stale image
+func avatarKey(userID uuid.UUID) string {
+	return "avatars/" + userID.String() + "_" + strconv.FormatInt(time.Now().UnixMilli(), 10) + ".jpg"
+}
+
+func (server *Server) updateAvatar(context *gin.Context) {
+	var req UpdateAvatarRequest
+	if err := context.ShouldBind(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !slices.Contains(SupportedImageTypes, req.Avatar.Header.Get("Content-Type")) {
+		context.AbortWithStatus(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	key := avatarKey(authorizedUser.ID)
+
+	_, err := server.s3Controller.Upload(context, req.Avatar, key)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	user, err := server.database.UpdateUsersAvatar(context, database.UpdateUsersAvatarParams{
+		AvatarKey: key,
+		ID:        authorizedUser.ID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	go server.deleteAvatar(authorizedUser.AvatarKey)
+
+	context.JSON(http.StatusOK, user.MakeResponse())
+}
+
+func (server *Server) removeAvatar(context *gin.Context) {
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	user, err := server.database.UpdateUsersAvatar(context, database.UpdateUsersAvatarParams{
+		AvatarKey: "",
+		ID:        authorizedUser.ID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	go server.deleteAvatar(authorizedUser.AvatarKey)
+
+	context.JSON(http.StatusOK, user.MakeResponse())
+}
+
+// deleteAvatar removes a replaced avatar from the bucket. the user already
+// points at the new one, so a failure only leaves an orphaned object behind
+func (server *Server) deleteAvatar(key string) {
+	if key == "" {
+		return
+	}
+
+	err := server.s3Controller.Delete(context.Background(), key)
+	if err != nil {
+		fmt.Println("failed to delete avatar " + key + ": " + err.Error())
+	}
+}
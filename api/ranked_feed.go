@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/ranking"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GetRankedFeedRequest struct {
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
+}
+
+// rankedFeedCursor is where the client stopped in the ranked feed. RankedAt is
+// the moment the first page was ranked at, every page ranks the same candidates
+// as of that moment and continues below the scores of the last posts it showed
+type rankedFeedCursor struct {
+	RankedAt time.Time `json:"t"`
+	ranking.Position
+}
+
+// getRankedFeed is the "for you" feed, recent posts from followed users and some
+// public ones from everyone else, ordered by ranking.Rank instead of by time
+func (server *Server) getRankedFeed(context *gin.Context) {
+	var req GetRankedFeedRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	cursor := rankedFeedCursor{RankedAt: time.Now()}
+
+	if req.Cursor != "" {
+		if err := util.DecodeCursorInto(req.Cursor, &cursor); err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		if cursor.Ranked < 0 || cursor.DiscoveredUsed < 0 {
+			context.JSON(http.StatusBadRequest, errorResponse(util.ErrInvalidCursor))
+			return
+		}
+	}
+
+	rankedAt := cursor.RankedAt
+
+	candidates, err := server.database.GetRankedFeedCandidates(context, database.GetRankedFeedCandidatesParams{
+		ViewerID:       authorizedUser.ID,
+		Since:          rankedAt.Add(-server.config.FeedCandidateWindow),
+		Until:          rankedAt,
+		CandidateLimit: server.config.FeedCandidateLimit,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	posts := make(map[uuid.UUID]database.GetRankedFeedCandidatesRow, len(candidates))
+	toRank := make([]ranking.Candidate, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		posts[candidate.ID] = candidate
+		toRank = append(toRank, ranking.Candidate{
+			PostID:    candidate.ID,
+			CreatedAt: candidate.CreatedAt,
+			Reactions: candidate.ReactionCount,
+			Comments:  candidate.CommentCount,
+			Affinity:  candidate.Affinity,
+			Followed:  candidate.IsFollowed,
+		})
+	}
+
+	ranked, position := ranking.RankFrom(toRank, server.feedWeights, rankedAt, cursor.Position, int(req.PageSize))
+
+	res := make([]database.PostResponse, 0)
+	for _, candidate := range ranked {
+		res = append(res, posts[candidate.PostID].MakeResponse())
+	}
+
+	if err := server.addPostReactions(context, res); err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	nextCursor := ""
+	if len(ranked) == int(req.PageSize) {
+		nextCursor = util.EncodeCursor(rankedFeedCursor{RankedAt: rankedAt, Position: position})
+	}
+
+	sendPage(context, 0, res, nextCursor)
+}
@@ -0,0 +1,556 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReactionUri struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// every user has at most one reaction on a post, comment or reply. sending the
+// one you already have takes it back, sending a different one replaces it
+type ToggleReactionRequest struct {
+	Reaction string `json:"reaction" binding:"required,oneof=like cube pb dnf plus_two"`
+}
+
+type toggleReactionResponse struct {
+	Reacted  bool   `json:"reacted"`
+	Reaction string `json:"reaction"`
+}
+
+type GetReactionsRequest struct {
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
+}
+
+// viewerID is the id of the user making the request, on routes behind
+// optionalAuthMiddleware there might not be one
+func viewerID(context *gin.Context) (uuid.UUID, bool) {
+	value, ok := context.Get(authorizationUserKey)
+	if !ok {
+		return uuid.Nil, false
+	}
+	return value.(database.User).ID, true
+}
+
+// reactionSummary holds the reaction counts of a page of posts, comments or
+// replies, and the reaction the viewer left on each of them
+type reactionSummary struct {
+	counts map[uuid.UUID]map[string]int64
+	mine   map[uuid.UUID]string
+}
+
+func newReactionSummary() reactionSummary {
+	return reactionSummary{
+		counts: make(map[uuid.UUID]map[string]int64),
+		mine:   make(map[uuid.UUID]string),
+	}
+}
+
+func (summary reactionSummary) add(id uuid.UUID, reaction string, count int64) {
+	if _, ok := summary.counts[id]; !ok {
+		summary.counts[id] = make(map[string]int64)
+	}
+	summary.counts[id][reaction] = count
+}
+
+func (summary reactionSummary) countsOf(id uuid.UUID) map[string]int64 {
+	if counts, ok := summary.counts[id]; ok {
+		return counts
+	}
+	return map[string]int64{}
+}
+
+func (server *Server) addPostReactions(context *gin.Context, posts []database.PostResponse) error {
+	ids := make([]uuid.UUID, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+
+	summary := newReactionSummary()
+
+	counts, err := server.database.GetPostReactionCounts(context, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		summary.add(count.PostID, count.Reaction, count.Count)
+	}
+
+	if userID, ok := viewerID(context); ok {
+		reactions, err := server.database.GetPostReactionsByUser(context, database.GetPostReactionsByUserParams{
+			UserID:  userID,
+			PostIds: ids,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, reaction := range reactions {
+			summary.mine[reaction.PostID] = reaction.Reaction
+		}
+	}
+
+	for i := range posts {
+		posts[i].ReactionCounts = summary.countsOf(posts[i].ID)
+		posts[i].MyReaction = summary.mine[posts[i].ID]
+		posts[i].ReactedByMe = posts[i].MyReaction != ""
+	}
+
+	return nil
+}
+
+func (server *Server) addCommentReactions(context *gin.Context, comments []database.CommentResponse) error {
+	ids := make([]uuid.UUID, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+
+	summary := newReactionSummary()
+
+	counts, err := server.database.GetCommentReactionCounts(context, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		summary.add(count.CommentID, count.Reaction, count.Count)
+	}
+
+	if userID, ok := viewerID(context); ok {
+		reactions, err := server.database.GetCommentReactionsByUser(context, database.GetCommentReactionsByUserParams{
+			UserID:     userID,
+			CommentIds: ids,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, reaction := range reactions {
+			summary.mine[reaction.CommentID] = reaction.Reaction
+		}
+	}
+
+	for i := range comments {
+		comments[i].ReactionCounts = summary.countsOf(comments[i].ID)
+		comments[i].MyReaction = summary.mine[comments[i].ID]
+		comments[i].ReactedByMe = comments[i].MyReaction != ""
+	}
+
+	return nil
+}
+
+func (server *Server) addReplyReactions(context *gin.Context, replies []database.ReplyResponse) error {
+	ids := make([]uuid.UUID, 0, len(replies))
+	for _, reply := range replies {
+		ids = append(ids, reply.ID)
+	}
+
+	summary := newReactionSummary()
+
+	counts, err := server.database.GetReplyReactionCounts(context, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		summary.add(count.ReplyID, count.Reaction, count.Count)
+	}
+
+	if userID, ok := viewerID(context); ok {
+		reactions, err := server.database.GetReplyReactionsByUser(context, database.GetReplyReactionsByUserParams{
+			UserID:   userID,
+			ReplyIds: ids,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, reaction := range reactions {
+			summary.mine[reaction.ReplyID] = reaction.Reaction
+		}
+	}
+
+	for i := range replies {
+		replies[i].ReactionCounts = summary.countsOf(replies[i].ID)
+		replies[i].MyReaction = summary.mine[replies[i].ID]
+		replies[i].ReactedByMe = replies[i].MyReaction != ""
+	}
+
+	return nil
+}
+
+func (server *Server) togglePostReaction(context *gin.Context) {
+	var uri ReactionUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ToggleReactionRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	postID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	post, ok := server.loadVisiblePost(context, postID)
+	if !ok {
+		return
+	}
+
+	if !server.checkNotBlocked(context, authorizedUser.ID, post.UserID) {
+		return
+	}
+
+	rowsAffected, err := server.database.DeletePostReaction(context, database.DeletePostReactionParams{
+		PostID:   postID,
+		UserID:   authorizedUser.ID,
+		Reaction: req.Reaction,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rowsAffected > 0 {
+		context.JSON(http.StatusOK, toggleReactionResponse{Reacted: false})
+		return
+	}
+
+	reaction, err := server.database.SetPostReaction(context, database.SetPostReactionParams{
+		PostID:   postID,
+		UserID:   authorizedUser.ID,
+		Reaction: req.Reaction,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, toggleReactionResponse{
+		Reacted:  true,
+		Reaction: reaction.Reaction,
+	})
+}
+
+func (server *Server) getPostReactions(context *gin.Context) {
+	var uri ReactionUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req GetReactionsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	postID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.loadVisiblePost(context, postID); !ok {
+		return
+	}
+
+	arg := database.GetPostReactionUsersParams{
+		PostID: postID,
+		Limit:  req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	rows, err := server.database.GetPostReactionUsers(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.ReactionUserResponse, 0)
+
+	for _, row := range rows {
+		res = append(res, row.MakeResponse())
+	}
+
+	nextCursor := ""
+	if len(rows) == int(req.PageSize) {
+		last := rows[len(rows)-1]
+		nextCursor = util.Cursor{CreatedAt: last.ReactedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
+}
+
+func (server *Server) toggleCommentReaction(context *gin.Context) {
+	var uri ReactionUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ToggleReactionRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	commentID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	comment, _, ok := server.loadVisibleComment(context, commentID)
+	if !ok {
+		return
+	}
+
+	if !server.checkNotBlocked(context, authorizedUser.ID, comment.UserID) {
+		return
+	}
+
+	rowsAffected, err := server.database.DeleteCommentReaction(context, database.DeleteCommentReactionParams{
+		CommentID: commentID,
+		UserID:    authorizedUser.ID,
+		Reaction:  req.Reaction,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rowsAffected > 0 {
+		context.JSON(http.StatusOK, toggleReactionResponse{Reacted: false})
+		return
+	}
+
+	reaction, err := server.database.SetCommentReaction(context, database.SetCommentReactionParams{
+		CommentID: commentID,
+		UserID:    authorizedUser.ID,
+		Reaction:  req.Reaction,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, toggleReactionResponse{
+		Reacted:  true,
+		Reaction: reaction.Reaction,
+	})
+}
+
+func (server *Server) getCommentReactions(context *gin.Context) {
+	var uri ReactionUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req GetReactionsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	commentID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, _, ok := server.loadVisibleComment(context, commentID); !ok {
+		return
+	}
+
+	arg := database.GetCommentReactionUsersParams{
+		CommentID: commentID,
+		Limit:     req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	rows, err := server.database.GetCommentReactionUsers(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.ReactionUserResponse, 0)
+
+	for _, row := range rows {
+		res = append(res, row.MakeResponse())
+	}
+
+	nextCursor := ""
+	if len(rows) == int(req.PageSize) {
+		last := rows[len(rows)-1]
+		nextCursor = util.Cursor{CreatedAt: last.ReactedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
+}
+
+func (server *Server) toggleReplyReaction(context *gin.Context) {
+	var uri ReactionUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ToggleReactionRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	replyID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	reply, ok := server.loadVisibleReply(context, replyID)
+	if !ok {
+		return
+	}
+
+	if !server.checkNotBlocked(context, authorizedUser.ID, reply.UserID) {
+		return
+	}
+
+	rowsAffected, err := server.database.DeleteReplyReaction(context, database.DeleteReplyReactionParams{
+		ReplyID:  replyID,
+		UserID:   authorizedUser.ID,
+		Reaction: req.Reaction,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rowsAffected > 0 {
+		context.JSON(http.StatusOK, toggleReactionResponse{Reacted: false})
+		return
+	}
+
+	reaction, err := server.database.SetReplyReaction(context, database.SetReplyReactionParams{
+		ReplyID:  replyID,
+		UserID:   authorizedUser.ID,
+		Reaction: req.Reaction,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, toggleReactionResponse{
+		Reacted:  true,
+		Reaction: reaction.Reaction,
+	})
+}
+
+func (server *Server) getReplyReactions(context *gin.Context) {
+	var uri ReactionUri
+	if err := context.ShouldBindUri(&uri); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req GetReactionsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	replyID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.loadVisibleReply(context, replyID); !ok {
+		return
+	}
+
+	arg := database.GetReplyReactionUsersParams{
+		ReplyID: replyID,
+		Limit:   req.PageSize,
+	}
+
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	rows, err := server.database.GetReplyReactionUsers(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]database.ReactionUserResponse, 0)
+
+	for _, row := range rows {
+		res = append(res, row.MakeResponse())
+	}
+
+	nextCursor := ""
+	if len(rows) == int(req.PageSize) {
+		last := rows[len(rows)-1]
+		nextCursor = util.Cursor{CreatedAt: last.ReactedAt, ID: last.ID}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
+}
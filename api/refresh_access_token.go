@@ -2,13 +2,21 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/token"
 	"github.com/gin-gonic/gin"
 )
 
+var errRefreshTokenReused = errors.New("refresh token has already been used")
+
+// every refresh rotates the refresh token. the old session is marked as used and
+// a new one is created in the same family, so presenting an already rotated token
+// means it leaked and the whole family gets blocked.
 func (server *Server) refreshAccessToken(context *gin.Context) {
-	refreshToken, err := context.Cookie("refresh_token")
+	refreshToken, err := context.Cookie(refreshTokenCookieName)
 	if err != nil {
 		context.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
@@ -31,7 +39,12 @@ func (server *Server) refreshAccessToken(context *gin.Context) {
 	}
 
 	if session.IsBlocked || session.UserID != payload.UserID {
-		context.JSON(http.StatusForbidden, errorResponse(err))
+		context.JSON(http.StatusForbidden, errorResponse(errInvalidSession))
+		return
+	}
+
+	if session.IsUsed {
+		server.blockReusedSessionFamily(context, session)
 		return
 	}
 
@@ -41,14 +54,52 @@ func (server *Server) refreshAccessToken(context *gin.Context) {
 		return
 	}
 
-	newAccessToken, _, err := server.tokenMaker.CreateToken(session.UserID, server.config.AccessTokenDuration)
+	var newRefreshToken string
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		rowsAffected, err := queries.MarkSessionAsUsed(context, session.ID)
+		if err != nil {
+			return err
+		}
+
+		// someone else rotated this session in the meantime
+		if rowsAffected == 0 {
+			return errRefreshTokenReused
+		}
+
+		newRefreshToken, _, err = server.createSession(context, queries, session.UserID, user.Role, session.FamilyID)
+		return err
+	})
+	if err != nil {
+		if err == errRefreshTokenReused {
+			server.blockReusedSessionFamily(context, session)
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	newAccessToken, _, err := server.tokenMaker.CreateToken(session.UserID, user.Role, token.TokenTypeAccess, server.config.AccessTokenDuration)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	server.setRefreshTokenCookie(context, newRefreshToken)
+
 	context.JSON(http.StatusOK, gin.H{
-		"access_token": newAccessToken,
-		"user":         user.MakeResponse(),
+		"access_token":  newAccessToken,
+		"refresh_token": newRefreshToken,
+		"user":          user.MakeResponse(),
 	})
 }
+
+func (server *Server) blockReusedSessionFamily(context *gin.Context, session database.Session) {
+	err := server.database.BlockSessionFamily(context, session.FamilyID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.clearRefreshTokenCookie(context)
+	context.JSON(http.StatusForbidden, errorResponse(errRefreshTokenReused))
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"github.com/dqrk0jeste/letscube-backend/util"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
@@ -31,46 +32,123 @@ func (server *Server) addRouter() {
 
 	usersRouter.POST("/", server.createUser)
 	usersRouter.POST("/login", server.loginUser)
+	usersRouter.POST("/login/2fa", server.loginWithTwoFactor)
+
+	usersRouter.POST("/verify-email", server.verifyEmail)
+	usersRouter.POST("/verify-email/resend", server.authMiddleware, server.resendVerificationEmail)
+
+	usersRouter.POST("/forgot-password", server.forgotPassword)
+	usersRouter.POST("/reset-password", server.resetPassword)
+
+	usersRouter.POST("/2fa/enroll", server.authMiddleware, server.enrollTwoFactor)
+	usersRouter.POST("/2fa/confirm", server.authMiddleware, server.confirmTwoFactor)
+	usersRouter.POST("/2fa/disable", server.authMiddleware, server.disableTwoFactor)
 
 	usersRouter.GET("/refresh", server.refreshAccessToken)
+	usersRouter.POST("/logout", server.logoutUser)
+
+	usersRouter.GET("/sessions", server.authMiddleware, server.getSessions)
+	usersRouter.DELETE("/sessions", server.authMiddleware, server.deleteAllSessions)
+	usersRouter.DELETE("/sessions/:id", server.authMiddleware, server.deleteSession)
+
+	usersRouter.POST("/tokens", server.authMiddleware, server.createPersonalAccessToken)
+	usersRouter.GET("/tokens", server.authMiddleware, server.getPersonalAccessTokens)
+	usersRouter.DELETE("/tokens/:id", server.authMiddleware, server.revokePersonalAccessToken)
+
+	usersRouter.GET("/me", server.scopedAuthMiddleware(scopeUsersRead), server.getMe)
+	usersRouter.DELETE("/me", server.authMiddleware, server.deleteMe)
+
+	usersRouter.PATCH("/me/profile", server.authMiddleware, server.updateProfile)
+	usersRouter.PUT("/me/avatar", server.authMiddleware, server.updateAvatar)
+	usersRouter.DELETE("/me/avatar", server.authMiddleware, server.removeAvatar)
+	usersRouter.PUT("/me/privacy", server.authMiddleware, server.updatePrivacy)
+
+	usersRouter.POST("/me/exports", server.authMiddleware, server.requestDataExport)
+	usersRouter.GET("/me/exports", server.authMiddleware, server.getDataExports)
+	usersRouter.GET("/me/exports/:id/download", server.authMiddleware, server.downloadDataExport)
 
 	usersRouter.PUT("/username", server.authMiddleware, server.updateUsersUsername)
 	usersRouter.PUT("/password", server.authMiddleware, server.updateUsersPassword)
 
-	usersRouter.POST("/follows/:id", server.authMiddleware, server.followUser)
-	usersRouter.DELETE("/follows/:id", server.authMiddleware, server.unfollowUser)
+	usersRouter.POST("/follows/:id", server.scopedAuthMiddleware(scopeFollowsWrite), server.followUser)
+	usersRouter.DELETE("/follows/:id", server.scopedAuthMiddleware(scopeFollowsWrite), server.unfollowUser)
+
+	usersRouter.GET("/follow-requests", server.scopedAuthMiddleware(scopeUsersRead), server.getReceivedFollowRequests)
+	usersRouter.GET("/follow-requests/sent", server.scopedAuthMiddleware(scopeUsersRead), server.getSentFollowRequests)
+	usersRouter.POST("/follow-requests/:id/approve", server.scopedAuthMiddleware(scopeFollowsWrite), server.approveFollowRequest)
+	usersRouter.DELETE("/follow-requests/:id", server.scopedAuthMiddleware(scopeFollowsWrite), server.declineFollowRequest)
+
+	usersRouter.POST("/blocks/:id", server.authMiddleware, server.blockUser)
+	usersRouter.DELETE("/blocks/:id", server.authMiddleware, server.unblockUser)
+	usersRouter.GET("/blocks", server.authMiddleware, server.getBlockedUsers)
+
+	usersRouter.POST("/mutes/:id", server.authMiddleware, server.muteUser)
+	usersRouter.DELETE("/mutes/:id", server.authMiddleware, server.unmuteUser)
+	usersRouter.GET("/mutes", server.authMiddleware, server.getMutedUsers)
 
-	usersRouter.GET("/followers", server.getFollowers)
-	usersRouter.GET("/following", server.getFollowing)
+	usersRouter.GET("/followers", server.optionalAuthMiddleware(scopeUsersRead), server.getFollowers)
+	usersRouter.GET("/following", server.optionalAuthMiddleware(scopeUsersRead), server.getFollowing)
 
 	usersRouter.GET("/followers/count/:id", server.getFollowersCount)
 	usersRouter.GET("/following/count/:id", server.getFollowingCount)
 
 	usersRouter.GET("/:id", server.getUserById)
-	usersRouter.GET("/", server.getUsersByUsername)
+	usersRouter.GET("/", server.optionalAuthMiddleware(scopeUsersRead), server.getUsersByUsername)
 
 	postsRouter := router.Group("/posts")
 
-	postsRouter.POST("/", server.authMiddleware, server.createPost)
-	postsRouter.DELETE("/:id", server.authMiddleware, server.deletePost)
+	postsRouter.POST("/", server.scopedAuthMiddleware(scopePostsWrite), server.verifiedUserMiddleware, server.createPost)
+	postsRouter.DELETE("/:id", server.scopedAuthMiddleware(scopePostsWrite), server.deletePost)
+	postsRouter.PATCH("/:id", server.scopedAuthMiddleware(scopePostsWrite), server.updatePost)
+	postsRouter.GET("/:id/revisions", server.scopedAuthMiddleware(scopePostsRead), server.getPostRevisions)
 
-	postsRouter.GET(":id", server.getPostById)
-	postsRouter.GET("/", server.getPostsByUser)
+	postsRouter.GET(":id", server.optionalAuthMiddleware(scopePostsRead), server.getPostById)
+	postsRouter.GET("/", server.optionalAuthMiddleware(scopePostsRead), server.getPostsByUser)
 
-	postsRouter.GET("/feed", server.authMiddleware, server.getFeed)
-	postsRouter.GET("/guest-feed", server.getGuestFeed)
+	postsRouter.GET("/feed", server.scopedAuthMiddleware(scopePostsRead), server.getFeed)
+	postsRouter.GET("/feed/ranked", server.scopedAuthMiddleware(scopePostsRead), server.getRankedFeed)
+	postsRouter.GET("/guest-feed", server.optionalAuthMiddleware(scopePostsRead), server.getGuestFeed)
+
+	postsRouter.PUT("/:id/reactions", server.scopedAuthMiddleware(scopeReactionsWrite), server.togglePostReaction)
+	postsRouter.GET("/:id/reactions", server.optionalAuthMiddleware(scopePostsRead), server.getPostReactions)
 
 	commentsRouter := postsRouter.Group("/comments")
 
-	commentsRouter.POST("/", server.authMiddleware, server.postComment)
-	commentsRouter.DELETE("/:id", server.authMiddleware, server.deleteComment)
-	commentsRouter.GET("/", server.getComments)
+	commentsRouter.POST("/", server.scopedAuthMiddleware(scopeCommentsWrite), server.verifiedUserMiddleware, server.postComment)
+	commentsRouter.DELETE("/:id", server.scopedAuthMiddleware(scopeCommentsWrite), server.deleteComment)
+	commentsRouter.PATCH("/:id", server.scopedAuthMiddleware(scopeCommentsWrite), server.updateComment)
+	commentsRouter.GET("/:id/revisions", server.scopedAuthMiddleware(scopePostsRead), server.getCommentRevisions)
+	commentsRouter.GET("/", server.optionalAuthMiddleware(scopePostsRead), server.getComments)
+
+	commentsRouter.PUT("/:id/reactions", server.scopedAuthMiddleware(scopeReactionsWrite), server.toggleCommentReaction)
+	commentsRouter.GET("/:id/reactions", server.optionalAuthMiddleware(scopePostsRead), server.getCommentReactions)
 
 	repliesRouter := commentsRouter.Group("/replies")
 
-	repliesRouter.POST("/", server.authMiddleware, server.postReply)
-	repliesRouter.DELETE("/:id", server.authMiddleware, server.deleteReply)
-	repliesRouter.GET("/", server.getReplies)
+	repliesRouter.POST("/", server.scopedAuthMiddleware(scopeCommentsWrite), server.verifiedUserMiddleware, server.postReply)
+	repliesRouter.DELETE("/:id", server.scopedAuthMiddleware(scopeCommentsWrite), server.deleteReply)
+	repliesRouter.PATCH("/:id", server.scopedAuthMiddleware(scopeCommentsWrite), server.updateReply)
+	repliesRouter.GET("/:id/revisions", server.scopedAuthMiddleware(scopePostsRead), server.getReplyRevisions)
+	repliesRouter.GET("/", server.optionalAuthMiddleware(scopePostsRead), server.getReplies)
+
+	repliesRouter.PUT("/:id/reactions", server.scopedAuthMiddleware(scopeReactionsWrite), server.toggleReplyReaction)
+	repliesRouter.GET("/:id/reactions", server.optionalAuthMiddleware(scopePostsRead), server.getReplyReactions)
+
+	messagesRouter := router.Group("/messages")
+
+	messagesRouter.POST("/", server.scopedAuthMiddleware(scopeMessagesWrite), server.sendMessage)
+	messagesRouter.GET("/", server.scopedAuthMiddleware(scopeMessagesRead), server.getConversation)
+	messagesRouter.GET("/conversations", server.scopedAuthMiddleware(scopeMessagesRead), server.getConversations)
+
+	adminRouter := router.Group("/admin", server.authMiddleware, server.roleMiddleware(util.RoleAdmin))
+
+	adminRouter.PUT("/users/:id/role", server.updateUsersRole)
+
+	router.GET("/search", server.optionalAuthMiddleware(scopePostsRead), server.search)
+
+	router.GET("/ws", server.serveWebSocket)
+
+	router.GET("/.well-known/token-keys", server.getTokenKeys)
 
 	server.router = router
 }
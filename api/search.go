@@ -0,0 +1,263 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var errCursorWithoutType = errors.New("a cursor can only be used when searching a single type")
+
+// q is passed to websearch_to_tsquery for posts and comments, so it takes
+// "quoted phrases", or and -excluded words the way search engines do. users
+// are matched by trigram similarity of the username and display name instead,
+// which puts up with typos
+type SearchRequest struct {
+	Query    string `form:"q" binding:"required,min=2,max=100"`
+	Type     string `form:"type" binding:"omitempty,oneof=posts comments users"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	Cursor   string `form:"cursor"`
+}
+
+// snippets are the part of the text around the matches, with the matched words
+// in <mark> tags and everything else escaped
+type postSearchResult struct {
+	Post    database.PostResponse `json:"post"`
+	Snippet string                `json:"snippet"`
+	Rank    float32               `json:"rank"`
+}
+
+type commentSearchResult struct {
+	Comment database.CommentResponse `json:"comment"`
+	PostID  uuid.UUID                `json:"post_id"`
+	Snippet string                   `json:"snippet"`
+	Rank    float32                  `json:"rank"`
+}
+
+type userSearchResult struct {
+	User database.UserResponse `json:"user"`
+	Rank float32               `json:"rank"`
+}
+
+// searchResponse is sent when no type is given, it holds the first page of
+// every type. the client searches again with a type to get further pages
+type searchResponse struct {
+	Posts    []postSearchResult    `json:"posts"`
+	Comments []commentSearchResult `json:"comments"`
+	Users    []userSearchResult    `json:"users"`
+}
+
+// search looks through posts, comments and users. results are ordered by how
+// well they match, so the cursor holds the Score of the last result next to its
+// time and id, or its username for users. private accounts only show up in post
+// and comment results for their followers, blocked users are left out both ways
+func (server *Server) search(context *gin.Context) {
+	var req SearchRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var cursor *util.Cursor
+
+	if req.Cursor != "" {
+		if req.Type == "" {
+			context.JSON(http.StatusBadRequest, errorResponse(errCursorWithoutType))
+			return
+		}
+
+		decoded, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		cursor = &decoded
+	}
+
+	viewer, _ := viewerID(context)
+
+	switch req.Type {
+	case "posts":
+		posts, err := server.searchPosts(context, req, viewer, cursor)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		nextCursor := ""
+		if len(posts) == int(req.PageSize) {
+			last := posts[len(posts)-1]
+			nextCursor = util.Cursor{CreatedAt: last.Post.CreatedAt, ID: last.Post.ID, Score: float64(last.Rank)}.Encode()
+		}
+
+		sendPage(context, 0, posts, nextCursor)
+	case "comments":
+		comments, err := server.searchComments(context, req, viewer, cursor)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		nextCursor := ""
+		if len(comments) == int(req.PageSize) {
+			last := comments[len(comments)-1]
+			nextCursor = util.Cursor{CreatedAt: last.Comment.CreatedAt, ID: last.Comment.ID, Score: float64(last.Rank)}.Encode()
+		}
+
+		sendPage(context, 0, comments, nextCursor)
+	case "users":
+		users, err := server.searchUsers(context, req, viewer, cursor)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		nextCursor := ""
+		if len(users) == int(req.PageSize) {
+			last := users[len(users)-1]
+			nextCursor = util.Cursor{Key: last.User.Username, Score: float64(last.Rank)}.Encode()
+		}
+
+		sendPage(context, 0, users, nextCursor)
+	default:
+		posts, err := server.searchPosts(context, req, viewer, nil)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		comments, err := server.searchComments(context, req, viewer, nil)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		users, err := server.searchUsers(context, req, viewer, nil)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		context.JSON(http.StatusOK, searchResponse{
+			Posts:    posts,
+			Comments: comments,
+			Users:    users,
+		})
+	}
+}
+
+func (server *Server) searchPosts(context *gin.Context, req SearchRequest, viewer uuid.UUID, cursor *util.Cursor) ([]postSearchResult, error) {
+	arg := database.SearchPostsParams{
+		Limit:    req.PageSize,
+		Query:    req.Query,
+		ViewerID: viewer,
+	}
+
+	if cursor != nil {
+		arg.CursorRank = sql.NullFloat64{Float64: cursor.Score, Valid: true}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	rows, err := server.database.SearchPosts(context, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]database.PostResponse, 0, len(rows))
+	for _, row := range rows {
+		posts = append(posts, row.MakeResponse())
+	}
+
+	if err := server.addPostReactions(context, posts); err != nil {
+		return nil, err
+	}
+
+	res := make([]postSearchResult, 0)
+
+	for i, row := range rows {
+		res = append(res, postSearchResult{
+			Post:    posts[i],
+			Snippet: util.HighlightSnippet(row.Snippet),
+			Rank:    row.Rank,
+		})
+	}
+
+	return res, nil
+}
+
+func (server *Server) searchComments(context *gin.Context, req SearchRequest, viewer uuid.UUID, cursor *util.Cursor) ([]commentSearchResult, error) {
+	arg := database.SearchCommentsParams{
+		Limit:    req.PageSize,
+		Query:    req.Query,
+		ViewerID: viewer,
+	}
+
+	if cursor != nil {
+		arg.CursorRank = sql.NullFloat64{Float64: cursor.Score, Valid: true}
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	rows, err := server.database.SearchComments(context, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]database.CommentResponse, 0, len(rows))
+	for _, row := range rows {
+		comments = append(comments, row.MakeResponse())
+	}
+
+	if err := server.addCommentReactions(context, comments); err != nil {
+		return nil, err
+	}
+
+	res := make([]commentSearchResult, 0)
+
+	for i, row := range rows {
+		res = append(res, commentSearchResult{
+			Comment: comments[i],
+			PostID:  row.PostID,
+			Snippet: util.HighlightSnippet(row.Snippet),
+			Rank:    row.Rank,
+		})
+	}
+
+	return res, nil
+}
+
+func (server *Server) searchUsers(context *gin.Context, req SearchRequest, viewer uuid.UUID, cursor *util.Cursor) ([]userSearchResult, error) {
+	arg := database.SearchUsersParams{
+		Limit:    req.PageSize,
+		Query:    req.Query,
+		ViewerID: viewer,
+	}
+
+	if cursor != nil {
+		arg.CursorRank = sql.NullFloat64{Float64: cursor.Score, Valid: true}
+		arg.CursorUsername = sql.NullString{String: cursor.Key, Valid: true}
+	}
+
+	rows, err := server.database.SearchUsers(context, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]userSearchResult, 0)
+
+	for _, row := range rows {
+		res = append(res, userSearchResult{
+			User: row.MakeResponse(),
+			Rank: row.Rank,
+		})
+	}
+
+	return res, nil
+}
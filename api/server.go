@@ -2,22 +2,36 @@ package api
 
 import (
 	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/lockout"
+	"github.com/dqrk0jeste/letscube-backend/mail"
+	"github.com/dqrk0jeste/letscube-backend/ranking"
+	"github.com/dqrk0jeste/letscube-backend/realtime"
 	"github.com/dqrk0jeste/letscube-backend/s3_bucket"
 	"github.com/dqrk0jeste/letscube-backend/token"
 	"github.com/dqrk0jeste/letscube-backend/util"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type Server struct {
-	config       util.Config
-	database     *database.Queries
-	tokenMaker   *token.PasetoMaker
-	router       *gin.Engine
-	s3Controller *s3_bucket.S3Controller
+	config         util.Config
+	database       *database.Store
+	tokenMaker     token.Maker
+	router         *gin.Engine
+	s3Controller   *s3_bucket.S3Controller
+	hub            *realtime.Hub
+	mailSender     mail.Sender
+	passwordPolicy util.PasswordPolicy
+	// failed logins are tracked per username and per ip, with separate limits
+	usernameLimiter *lockout.Limiter
+	ipLimiter       *lockout.Limiter
+	// compared against when the username doesn't exist, see loginUser
+	dummyPasswordHash string
+	feedWeights       ranking.Weights
 }
 
-func CreateServer(config util.Config, database *database.Queries) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSecret)
+func CreateServer(config util.Config, database *database.Store) (*Server, error) {
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, err
 	}
@@ -27,11 +41,50 @@ func CreateServer(config util.Config, database *database.Queries) (*Server, erro
 		return nil, err
 	}
 
+	mailSender, err := mail.NewSender(config)
+	if err != nil {
+		return nil, err
+	}
+
+	loginAttemptStore, err := lockout.NewStore(config, database)
+	if err != nil {
+		return nil, err
+	}
+
+	dummyPasswordHash, err := util.GeneratePasswordHash(uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+
 	server := &Server{
-		config:       config,
-		database:     database,
-		tokenMaker:   tokenMaker,
-		s3Controller: s3Controller,
+		config:         config,
+		database:       database,
+		tokenMaker:     tokenMaker,
+		s3Controller:   s3Controller,
+		hub:            realtime.NewHub(),
+		mailSender:     mailSender,
+		passwordPolicy: util.NewPasswordPolicy(config),
+		usernameLimiter: lockout.NewLimiter(loginAttemptStore, lockout.Policy{
+			MaxAttempts: config.LoginMaxAttempts,
+			BaseLockout: config.LoginBaseLockout,
+			MaxLockout:  config.LoginMaxLockout,
+			ResetAfter:  config.LoginAttemptResetAfter,
+		}),
+		ipLimiter: lockout.NewLimiter(loginAttemptStore, lockout.Policy{
+			MaxAttempts: config.LoginIPMaxAttempts,
+			BaseLockout: config.LoginBaseLockout,
+			MaxLockout:  config.LoginMaxLockout,
+			ResetAfter:  config.LoginAttemptResetAfter,
+		}),
+		dummyPasswordHash: dummyPasswordHash,
+		feedWeights: ranking.Weights{
+			RecencyHalfLife: config.FeedRecencyHalfLife,
+			Reactions:       config.FeedReactionWeight,
+			Comments:        config.FeedCommentWeight,
+			Affinity:        config.FeedAffinityWeight,
+			DiscoveryFactor: config.FeedDiscoveryFactor,
+			DiscoveryShare:  config.FeedDiscoveryShare,
+		},
 	}
 
 	server.addRouter()
@@ -40,6 +93,10 @@ func CreateServer(config util.Config, database *database.Queries) (*Server, erro
 }
 
 func (server *Server) Start(address string) error {
+	go server.runAccountPurger()
+	go server.runDataExportCleanup()
+	go server.resumeDataExports()
+
 	return server.router.Run(address)
 }
 
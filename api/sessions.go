@@ -0,0 +1,189 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/token"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const refreshTokenCookieName = "refresh_token"
+
+var errInvalidSession = errors.New("invalid session")
+
+// createSession issues a new refresh token and stores the session for it. sessions
+// created by rotating the same login share a family, uuid.Nil starts a new one.
+func (server *Server) createSession(
+	context *gin.Context,
+	queries *database.Queries,
+	userID uuid.UUID,
+	role string,
+	familyID uuid.UUID,
+) (string, database.Session, error) {
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(userID, role, token.TokenTypeRefresh, server.config.RefreshTokenDuration)
+	if err != nil {
+		return "", database.Session{}, err
+	}
+
+	if familyID == uuid.Nil {
+		familyID = refreshPayload.ID
+	}
+
+	session, err := queries.CreateSession(context, database.CreateSessionParams{
+		ID:           refreshPayload.ID,
+		UserID:       refreshPayload.UserID,
+		RefreshToken: refreshToken,
+		ClientIp:     context.ClientIP(),
+		IsBlocked:    false,
+		ExpiresAt:    refreshPayload.ExpiredAt,
+		FamilyID:     familyID,
+	})
+	if err != nil {
+		return "", database.Session{}, err
+	}
+
+	return refreshToken, session, nil
+}
+
+func (server *Server) setRefreshTokenCookie(context *gin.Context, refreshToken string) {
+	maxAge := int(server.config.RefreshTokenDuration.Seconds())
+	context.SetCookie(refreshTokenCookieName, refreshToken, maxAge, "/", "", false, true)
+}
+
+func (server *Server) clearRefreshTokenCookie(context *gin.Context) {
+	context.SetCookie(refreshTokenCookieName, "", -1, "/", "", false, true)
+}
+
+// the refresh token cookie is the only thing that ties a request to a session,
+// access tokens are not bound to one
+func (server *Server) getCurrentSession(context *gin.Context) (database.Session, error) {
+	refreshToken, err := context.Cookie(refreshTokenCookieName)
+	if err != nil {
+		return database.Session{}, err
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(refreshToken)
+	if err != nil {
+		return database.Session{}, err
+	}
+
+	session, err := server.database.GetSessionById(context, payload.ID)
+	if err != nil {
+		return database.Session{}, err
+	}
+
+	if session.IsBlocked || session.UserID != payload.UserID {
+		return database.Session{}, errInvalidSession
+	}
+
+	return session, nil
+}
+
+// revokeOtherSessions blocks every session of the user except the one the request
+// was made from, or all of them if the request didn't come with a valid session
+func (server *Server) revokeOtherSessions(context *gin.Context, userID uuid.UUID) error {
+	session, err := server.getCurrentSession(context)
+	if err != nil || session.UserID != userID {
+		return server.database.BlockUserSessions(context, userID)
+	}
+
+	return server.database.BlockOtherUserSessions(context, database.BlockOtherUserSessionsParams{
+		UserID: userID,
+		ID:     session.ID,
+	})
+}
+
+func (server *Server) logoutUser(context *gin.Context) {
+	session, err := server.getCurrentSession(context)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	_, err = server.database.BlockSession(context, database.BlockSessionParams{
+		ID:     session.ID,
+		UserID: session.UserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.clearRefreshTokenCookie(context)
+	context.Status(http.StatusOK)
+}
+
+func (server *Server) getSessions(context *gin.Context) {
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+
+	sessions, err := server.database.GetActiveSessionsByUser(context, authorizationPayload.UserID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	currentSession, err := server.getCurrentSession(context)
+	hasCurrentSession := err == nil
+
+	res := make([]database.SessionResponse, 0)
+
+	for _, session := range sessions {
+		sessionResponse := session.MakeResponse()
+		sessionResponse.Current = hasCurrentSession && session.ID == currentSession.ID
+		res = append(res, sessionResponse)
+	}
+
+	context.JSON(http.StatusOK, res)
+}
+
+type DeleteSessionRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+func (server *Server) deleteSession(context *gin.Context) {
+	var req DeleteSessionRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+
+	rowsAffected, err := server.database.BlockSession(context, database.BlockSessionParams{
+		ID:     id,
+		UserID: authorizationPayload.UserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rowsAffected == 0 {
+		context.Status(http.StatusNotFound)
+		return
+	}
+
+	context.Status(http.StatusOK)
+}
+
+func (server *Server) deleteAllSessions(context *gin.Context) {
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+
+	err := server.database.BlockUserSessions(context, authorizationPayload.UserID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.clearRefreshTokenCookie(context)
+	context.Status(http.StatusOK)
+}
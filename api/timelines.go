@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/google/uuid"
+)
+
+// how many of the followed user's latest posts a new follower gets in their
+// timeline right away, older ones are only on the followed user's profile
+const timelineBackfillSize = 100
+
+// fanOutPost pushes a new post into the timelines of the author's followers,
+// which is what getFeed reads from. accounts with more followers than
+// TimelineFanoutMaxFollowers are not pushed anywhere, getFeed pulls their posts
+// when it reads instead. once an account is pulled it stays that way, its
+// posts from before would be missing from the timelines otherwise
+func (server *Server) fanOutPost(ctx context.Context, queries *database.Queries, post database.Post) error {
+	pull, err := queries.IsTimelinePullAuthor(ctx, post.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !pull {
+		followersCount, err := queries.GetFollowersCount(ctx, post.UserID)
+		if err != nil {
+			return err
+		}
+
+		if followersCount > server.config.TimelineFanoutMaxFollowers {
+			err = queries.AddTimelinePullAuthor(ctx, post.UserID)
+			if err != nil {
+				return err
+			}
+			pull = true
+		}
+	}
+
+	if pull {
+		return nil
+	}
+
+	return queries.FanOutPost(ctx, post.ID)
+}
+
+// backfillTimeline fills the timeline of a new follower with the latest posts of
+// the user they followed. the follow has to exist already
+func backfillTimeline(ctx context.Context, queries *database.Queries, userID uuid.UUID, followedUserID uuid.UUID) error {
+	return queries.BackfillTimeline(ctx, database.BackfillTimelineParams{
+		UserID:         userID,
+		FollowedUserID: followedUserID,
+		Limit:          timelineBackfillSize,
+	})
+}
+
+// backfillFollowersTimelines does the same for every follower of a user at once,
+// each gets the same latest posts. pulled accounts are skipped, getFeed reads
+// their posts without the timelines
+func backfillFollowersTimelines(ctx context.Context, queries *database.Queries, followedUserID uuid.UUID) error {
+	return queries.BackfillFollowersTimelines(ctx, database.BackfillFollowersTimelinesParams{
+		FollowedUserID: followedUserID,
+		Limit:          timelineBackfillSize,
+	})
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dqrk0jeste/letscube-backend/token"
+	"github.com/gin-gonic/gin"
+)
+
+type getTokenKeysResponse struct {
+	Keys []token.PublicKey `json:"keys"`
+}
+
+// getTokenKeys publishes the keys other services need to verify access tokens
+// on their own. only makers that sign with public keys have anything to publish
+func (server *Server) getTokenKeys(context *gin.Context) {
+	var keys []token.PublicKey
+	if publisher, ok := server.tokenMaker.(token.KeyPublisher); ok {
+		keys = publisher.PublicKeys()
+	}
+
+	if len(keys) == 0 {
+		err := errors.New("tokens can't be verified with public keys")
+		context.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, getTokenKeysResponse{
+		Keys: keys,
+	})
+}
@@ -0,0 +1,410 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/token"
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const numberOfRecoveryCodes = 10
+
+var (
+	errTwoFactorAlreadyEnabled = errors.New("two factor authentication is already enabled")
+	errTwoFactorNotEnabled     = errors.New("two factor authentication is not enabled")
+	errInvalidTwoFactorCode    = errors.New("invalid two factor code")
+	errInvalidMfaToken         = errors.New("invalid or expired mfa token")
+)
+
+// createMfaToken issues the short lived token that stands between a correct
+// password and a session when the user has two factor authentication enabled
+func (server *Server) createMfaToken(context *gin.Context, userID uuid.UUID) (string, error) {
+	mfaToken, err := util.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = server.database.CreateMfaToken(context, database.CreateMfaTokenParams{
+		ID:        id,
+		UserID:    userID,
+		TokenHash: util.HashToken(mfaToken),
+		ExpiresAt: time.Now().Add(server.config.MfaTokenDuration),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return mfaToken, nil
+}
+
+// verifySecondFactor accepts either a totp code or one of the recovery codes.
+// both can be used only once.
+func (server *Server) verifySecondFactor(context *gin.Context, userID uuid.UUID, code string) error {
+	totpSecret, err := server.database.GetTotpSecretByUser(context, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errTwoFactorNotEnabled
+		}
+		return err
+	}
+
+	if !totpSecret.IsEnabled {
+		return errTwoFactorNotEnabled
+	}
+
+	if counter, ok := util.ValidateTOTP(totpSecret.Secret, code, time.Now()); ok {
+		rowsAffected, err := server.database.UseTotpCounter(context, database.UseTotpCounterParams{
+			UserID:          userID,
+			LastUsedCounter: counter,
+		})
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return errInvalidTwoFactorCode
+		}
+
+		return nil
+	}
+
+	rowsAffected, err := server.database.UseRecoveryCode(context, database.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: util.HashToken(util.NormalizeRecoveryCode(code)),
+	})
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// enrolling and confirming both ask for the password again, otherwise anyone
+// holding a stolen access token could set up their own authenticator and lock
+// the owner out
+type EnrollTwoFactorRequest struct {
+	Password string `json:"password" binding:"required,printascii"`
+}
+
+type enrollTwoFactorResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+// enrolling doesn't enable anything yet, the user has to confirm with a first code
+func (server *Server) enrollTwoFactor(context *gin.Context) {
+	var req EnrollTwoFactorRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+
+	user, err := server.database.GetUserById(context, authorizationPayload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = util.VerifyPassword(req.Password, user.PasswordHash)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	totpSecret, err := server.database.GetTotpSecretByUser(context, user.ID)
+	if err != nil && err != sql.ErrNoRows {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err == nil && totpSecret.IsEnabled {
+		context.JSON(http.StatusConflict, errorResponse(errTwoFactorAlreadyEnabled))
+		return
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = server.database.UpsertTotpSecret(context, database.UpsertTotpSecretParams{
+		UserID: user.ID,
+		Secret: secret,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, enrollTwoFactorResponse{
+		Secret:     secret,
+		OtpauthURI: util.TOTPURI(server.config.TotpIssuer, user.Username, secret),
+	})
+}
+
+type ConfirmTwoFactorRequest struct {
+	Password string `json:"password" binding:"required,printascii"`
+	Code     string `json:"code" binding:"required,numeric,len=6"`
+}
+
+type confirmTwoFactorResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (server *Server) confirmTwoFactor(context *gin.Context) {
+	var req ConfirmTwoFactorRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+	userID := authorizationPayload.UserID
+
+	user, err := server.database.GetUserById(context, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = util.VerifyPassword(req.Password, user.PasswordHash)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	totpSecret, err := server.database.GetTotpSecretByUser(context, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if totpSecret.IsEnabled {
+		context.JSON(http.StatusConflict, errorResponse(errTwoFactorAlreadyEnabled))
+		return
+	}
+
+	counter, ok := util.ValidateTOTP(totpSecret.Secret, req.Code, time.Now())
+	if !ok {
+		context.JSON(http.StatusUnauthorized, errorResponse(errInvalidTwoFactorCode))
+		return
+	}
+
+	recoveryCodes, err := util.GenerateRecoveryCodes(numberOfRecoveryCodes)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		err := queries.EnableTotp(context, userID)
+		if err != nil {
+			return err
+		}
+
+		_, err = queries.UseTotpCounter(context, database.UseTotpCounterParams{
+			UserID:          userID,
+			LastUsedCounter: counter,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = queries.DeleteRecoveryCodes(context, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, recoveryCode := range recoveryCodes {
+			id, err := uuid.NewRandom()
+			if err != nil {
+				return err
+			}
+
+			err = queries.CreateRecoveryCode(context, database.CreateRecoveryCodeParams{
+				ID:       id,
+				UserID:   userID,
+				CodeHash: util.HashToken(recoveryCode),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, confirmTwoFactorResponse{
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required,printascii"`
+	Code     string `json:"code" binding:"required"`
+}
+
+func (server *Server) disableTwoFactor(context *gin.Context) {
+	var req DisableTwoFactorRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+
+	user, err := server.database.GetUserById(context, authorizationPayload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = util.VerifyPassword(req.Password, user.PasswordHash)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	err = server.verifySecondFactor(context, user.ID, req.Code)
+	if err != nil {
+		switch err {
+		case errTwoFactorNotEnabled:
+			context.JSON(http.StatusConflict, errorResponse(err))
+		case errInvalidTwoFactorCode:
+			context.JSON(http.StatusUnauthorized, errorResponse(err))
+		default:
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		err := queries.DeleteTotpSecret(context, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return queries.DeleteRecoveryCodes(context, user.ID)
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.Status(http.StatusOK)
+}
+
+type LoginWithTwoFactorRequest struct {
+	MfaToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// second step of the login for users with two factor authentication enabled
+func (server *Server) loginWithTwoFactor(context *gin.Context) {
+	var req LoginWithTwoFactorRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	mfaToken, err := server.database.GetMfaTokenByHash(context, util.HashToken(req.MfaToken))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusUnauthorized, errorResponse(errInvalidMfaToken))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if mfaToken.UsedAt.Valid || time.Now().After(mfaToken.ExpiresAt) {
+		context.JSON(http.StatusUnauthorized, errorResponse(errInvalidMfaToken))
+		return
+	}
+
+	user, err := server.database.GetUserById(context, mfaToken.UserID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the code is only six digits, so it's throttled the same way passwords are
+	if !server.checkLoginLockout(context, user.Username) {
+		return
+	}
+
+	err = server.verifySecondFactor(context, mfaToken.UserID, req.Code)
+	if err != nil {
+		if err == errInvalidTwoFactorCode || err == errTwoFactorNotEnabled {
+			server.auditLogin(context, auditActionLoginMfaFailed, user.Username, user.ID)
+
+			if err := server.recordLoginFailure(context, user.Username); err != nil {
+				context.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+
+			context.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rowsAffected, err := server.database.UseMfaToken(context, mfaToken.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rowsAffected == 0 {
+		context.JSON(http.StatusUnauthorized, errorResponse(errInvalidMfaToken))
+		return
+	}
+
+	if err := server.recordLoginSuccess(context, user.Username); err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.auditLogin(context, auditActionLoginSucceeded, user.Username, user.ID)
+
+	server.respondWithNewSession(context, user)
+}
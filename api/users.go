@@ -2,6 +2,7 @@ package api
 
 import (
 	"database/sql"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -15,7 +16,7 @@ import (
 
 type CreateUserRequest struct {
 	Username string `json:"username" binding:"required,printascii,min=1,max=20,excludesrune= "`
-	Password string `json:"password" binding:"required,printascii,min=6"`
+	Password string `json:"password" binding:"required,printascii"`
 	Email    string `json:"email" binding:"required,email"`
 }
 
@@ -26,6 +27,11 @@ func (server *Server) createUser(context *gin.Context) {
 		return
 	}
 
+	if err := server.passwordPolicy.Validate(req.Password, req.Username); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	id, err := uuid.NewRandom()
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -57,6 +63,13 @@ func (server *Server) createUser(context *gin.Context) {
 		return
 	}
 
+	// the account is already created at this point, if sending fails the user can
+	// ask for a new email
+	err = server.sendVerificationEmail(context, user)
+	if err != nil {
+		fmt.Println("there has been an error sending verification email to user " + user.ID.String())
+	}
+
 	context.JSON(http.StatusCreated, user.MakeResponse())
 }
 
@@ -66,12 +79,18 @@ type loginUserRequest struct {
 }
 
 type loginUserResponse struct {
-	AccessToken  string                `json:"access_token"`
-	RefreshToken string                `json:"refresh_token"`
-	User         database.UserResponse `json:"user"`
+	AccessToken   string                `json:"access_token"`
+	RefreshToken  string                `json:"refresh_token"`
+	EmailVerified bool                  `json:"email_verified"`
+	Role          string                `json:"role"`
+	User          database.UserResponse `json:"user"`
+}
+
+type loginMfaRequiredResponse struct {
+	MfaRequired bool   `json:"mfa_required"`
+	MfaToken    string `json:"mfa_token"`
 }
 
-// TODO: add email verification
 func (server *Server) loginUser(context *gin.Context) {
 	var req loginUserRequest
 	if err := context.ShouldBindJSON(&req); err != nil {
@@ -79,47 +98,111 @@ func (server *Server) loginUser(context *gin.Context) {
 		return
 	}
 
+	if !server.checkLoginLockout(context, req.Username) {
+		return
+	}
+
 	user, err := server.database.GetUserByUsername(context, req.Username)
+	if err != nil && err != sql.ErrNoRows {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err == nil && server.accountGone(user) {
+		err = sql.ErrNoRows
+	}
+
+	if err == sql.ErrNoRows {
+		// still pay for a bcrypt comparison, so the response time doesn't tell
+		// whether the username exists
+		util.VerifyPassword(req.Password, server.dummyPasswordHash)
+	} else {
+		err = util.VerifyPassword(req.Password, user.PasswordHash)
+	}
 	if err != nil {
-		if err == sql.ErrNoRows {
-			context.JSON(http.StatusNotFound, errorResponse(err))
+		server.auditLogin(context, auditActionLoginFailed, req.Username, user.ID)
+
+		if err := server.recordLoginFailure(context, req.Username); err != nil {
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
 			return
 		}
+
+		context.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+		return
+	}
+
+	totpSecret, err := server.database.GetTotpSecretByUser(context, user.ID)
+	if err != nil && err != sql.ErrNoRows {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	err = util.VerifyPassword(req.Password, user.PasswordHash)
-	if err != nil {
-		context.JSON(http.StatusUnauthorized, errorResponse(err))
+	if err == nil && totpSecret.IsEnabled {
+		mfaToken, err := server.createMfaToken(context, user.ID)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		server.auditLogin(context, auditActionLoginMfaRequired, user.Username, user.ID)
+
+		context.JSON(http.StatusOK, loginMfaRequiredResponse{
+			MfaRequired: true,
+			MfaToken:    mfaToken,
+		})
+		return
+	}
+
+	if err := server.recordLoginSuccess(context, user.Username); err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	accessToken, _, err := server.tokenMaker.CreateToken(user.ID, server.config.AccessTokenDuration)
+	server.auditLogin(context, auditActionLoginSucceeded, user.Username, user.ID)
+
+	server.respondWithNewSession(context, user)
+}
+
+// respondWithNewSession is the last step of every login flow, it issues both
+// tokens for the user and sends them back. logging in during the deletion grace
+// period cancels the deletion, unless the purger claimed the account first.
+func (server *Server) respondWithNewSession(context *gin.Context, user database.User) {
+	if user.DeletedAt.Valid {
+		restoredUser, err := server.database.RestoreUser(context, database.RestoreUserParams{
+			ID:           user.ID,
+			DeletedAfter: sql.NullTime{Time: server.graceStart(), Valid: true},
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				context.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+				return
+			}
+			context.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		user = restoredUser
+	}
+
+	accessToken, _, err := server.tokenMaker.CreateToken(user.ID, user.Role, token.TokenTypeAccess, server.config.AccessTokenDuration)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.ID, server.config.RefreshTokenDuration)
+	refreshToken, _, err := server.createSession(context, server.database.Queries, user.ID, user.Role, uuid.Nil)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	server.database.CreateSession(context, database.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		UserID:       refreshPayload.UserID,
-		RefreshToken: refreshToken,
-		ClientIp:     context.ClientIP(),
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiredAt,
-	})
+	server.setRefreshTokenCookie(context, refreshToken)
 
 	context.JSON(http.StatusOK, loginUserResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		User:         user.MakeResponse(),
+		AccessToken:   accessToken,
+		RefreshToken:  refreshToken,
+		EmailVerified: user.IsVerified,
+		Role:          user.Role,
+		User:          user.MakeResponse(),
 	})
 }
 
@@ -150,13 +233,19 @@ func (server *Server) getUserById(context *gin.Context) {
 		return
 	}
 
+	if user.DeletedAt.Valid {
+		context.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
 	context.JSON(http.StatusOK, user.MakeResponse())
 }
 
 type GetUsersByUsernameRequest struct {
-	Page     int32  `form:"page_number" binding:"required,min=1"`
+	Page     int32  `form:"page_number" binding:"omitempty,min=1"`
 	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
 	Input    string `form:"input" binding:"required,min=3,printascii"`
+	Cursor   string `form:"cursor"`
 }
 
 func (server *Server) getUsersByUsername(context *gin.Context) {
@@ -167,11 +256,24 @@ func (server *Server) getUsersByUsername(context *gin.Context) {
 	}
 
 	arg := database.GetUsersByUsernameParams{
-		Input:  strings.Trim(req.Input, " ") + "%",
-		Offset: (req.Page - 1) * req.PageSize,
-		Limit:  req.PageSize,
+		Input: strings.Trim(req.Input, " ") + "%",
+		Limit: req.PageSize,
 	}
 
+	if req.Page > 0 {
+		arg.Offset = (req.Page - 1) * req.PageSize
+	} else if req.Cursor != "" {
+		cursor, err := util.DecodeCursor(req.Cursor)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorUsername = sql.NullString{String: cursor.Key, Valid: true}
+	}
+
+	// anonymous searches have no blocks to hide, uuid.Nil matches nobody
+	arg.ViewerID, _ = viewerID(context)
+
 	users, err := server.database.GetUsersByUsername(context, arg)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -184,11 +286,18 @@ func (server *Server) getUsersByUsername(context *gin.Context) {
 		res = append(res, user.MakeResponse())
 	}
 
-	context.JSON(http.StatusOK, res)
+	nextCursor := ""
+	if len(users) == int(req.PageSize) {
+		last := users[len(users)-1]
+		nextCursor = util.Cursor{Key: last.Username}.Encode()
+	}
+
+	sendPage(context, req.Page, res, nextCursor)
 }
 
 type UpdateUsersUsernameRequest struct {
-	Username string `json:"username" binding:"required,printascii,min=1,max=20"`
+	Username        string `json:"username" binding:"required,printascii,min=1,max=20"`
+	CurrentPassword string `json:"current_password" binding:"required,printascii"`
 }
 
 func (server *Server) updateUsersUsername(context *gin.Context) {
@@ -198,10 +307,16 @@ func (server *Server) updateUsersUsername(context *gin.Context) {
 		return
 	}
 
-	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	err := util.VerifyPassword(req.CurrentPassword, authorizedUser.PasswordHash)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
 
 	arg := database.UpdateUsersUsernameParams{
-		ID:       authorizationPayload.UserID,
+		ID:       authorizedUser.ID,
 		Username: req.Username,
 	}
 
@@ -221,9 +336,13 @@ func (server *Server) updateUsersUsername(context *gin.Context) {
 }
 
 type UpdateUsersPasswordRequest struct {
-	Password string `json:"password" binding:"required,printascii,min=6,max=60"`
+	CurrentPassword string `json:"current_password" binding:"required,printascii"`
+	Password        string `json:"password" binding:"required,printascii"`
 }
 
+// changing the password invalidates every access token issued before it, including
+// the one used for this request, so a fresh one is sent back. personal access
+// tokens are revoked too, they would outlive the change otherwise
 func (server *Server) updateUsersPassword(context *gin.Context) {
 	var req UpdateUsersPasswordRequest
 	if err := context.ShouldBindJSON(&req); err != nil {
@@ -231,7 +350,18 @@ func (server *Server) updateUsersPassword(context *gin.Context) {
 		return
 	}
 
-	authorizationPayload := context.MustGet("authorization_payload").(*token.Payload)
+	authorizedUser := context.MustGet(authorizationUserKey).(database.User)
+
+	err := util.VerifyPassword(req.CurrentPassword, authorizedUser.PasswordHash)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if err := server.passwordPolicy.Validate(req.Password, authorizedUser.Username); err != nil {
+		context.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
 	passwordHash, err := util.GeneratePasswordHash(req.Password)
 	if err != nil {
@@ -239,18 +369,40 @@ func (server *Server) updateUsersPassword(context *gin.Context) {
 		return
 	}
 
-	arg := database.UpdateUsersPasswordParams{
-		ID:           authorizationPayload.UserID,
-		PasswordHash: passwordHash,
+	var user database.User
+	err = server.database.ExecTx(context, func(queries *database.Queries) error {
+		var err error
+		user, err = queries.UpdateUsersPassword(context, database.UpdateUsersPasswordParams{
+			ID:           authorizedUser.ID,
+			PasswordHash: passwordHash,
+		})
+		if err != nil {
+			return err
+		}
+
+		return queries.RevokeUserPersonalAccessTokens(context, authorizedUser.ID)
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	user, err := server.database.UpdateUsersPassword(context, arg)
+	err = server.revokeOtherSessions(context, user.ID)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	context.JSON(http.StatusOK, user.MakeResponse())
+	accessToken, _, err := server.tokenMaker.CreateToken(user.ID, user.Role, token.TokenTypeAccess, server.config.AccessTokenDuration)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{
+		"access_token": accessToken,
+		"user":         user.MakeResponse(),
+	})
 }
 
 // type GetUserByUsernameRequest struct {
@@ -318,3 +470,11 @@ func (server *Server) updateUsersPassword(context *gin.Context) {
 // 	context.JSON(http.StatusOK, makeUserResponse(user))
 // 	return
 // }
+
+// getMe is mostly there for scripts using personal access tokens, to find out
+// whose token they hold
+func (server *Server) getMe(context *gin.Context) {
+	user := context.MustGet(authorizationUserKey).(database.User)
+
+	context.JSON(http.StatusOK, user.MakeResponse())
+}
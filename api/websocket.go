@@ -0,0 +1,69 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dqrk0jeste/letscube-backend/realtime"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(request *http.Request) bool {
+		origin := request.Header.Get("Origin")
+		return origin == "" || slices.Contains(ALLOWED_ORIGINS, origin)
+	},
+}
+
+// browsers can't set headers on a websocket handshake, so the access token can
+// also be passed as a query parameter
+func (server *Server) serveWebSocket(context *gin.Context) {
+	accessToken := context.Query("access_token")
+
+	if authorizationHeader := context.GetHeader(authorizationHeaderKey); len(authorizationHeader) != 0 {
+		fields := strings.Fields(authorizationHeader)
+		if len(fields) != 2 || strings.ToLower(fields[0]) != authorizationTypeBearer {
+			err := errors.New("invalid authorization header format")
+			context.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		accessToken = fields[1]
+	}
+
+	if len(accessToken) == 0 {
+		err := errors.New("access token is not provided")
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(accessToken)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	_, err = server.getAuthorizedUser(context, payload)
+	if err != nil {
+		if err == sql.ErrNoRows || err == errTokenRevoked || err == errNotAccessToken {
+			context.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// on failure the upgrader has already written the error response
+	connection, err := upgrader.Upgrade(context.Writer, context.Request, nil)
+	if err != nil {
+		return
+	}
+
+	client := realtime.NewClient(server.hub, payload.UserID, connection, payload.ExpiredAt)
+	go client.Run()
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS messages_created_at_idx;
+
+ALTER TABLE messages DROP COLUMN read_at;
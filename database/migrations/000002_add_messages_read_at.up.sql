@@ -0,0 +1,3 @@
+ALTER TABLE messages ADD COLUMN read_at TIMESTAMPTZ;
+
+CREATE INDEX ON messages (created_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS sessions_family_id_idx;
+DROP INDEX IF EXISTS sessions_user_id_idx;
+
+ALTER TABLE sessions DROP COLUMN is_used;
+ALTER TABLE sessions DROP COLUMN family_id;
@@ -0,0 +1,8 @@
+ALTER TABLE sessions ADD COLUMN family_id UUID;
+UPDATE sessions SET family_id = id;
+ALTER TABLE sessions ALTER COLUMN family_id SET NOT NULL;
+
+ALTER TABLE sessions ADD COLUMN is_used BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX ON sessions (user_id);
+CREATE INDEX ON sessions (family_id);
@@ -0,0 +1,3 @@
+DROP TABLE email_verification_tokens;
+
+ALTER TABLE users DROP COLUMN is_verified;
@@ -0,0 +1,15 @@
+-- accounts from before verification existed never got an email to verify
+-- with, so they start out verified. only new accounts have to verify
+ALTER TABLE users ADD COLUMN is_verified BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE users ALTER COLUMN is_verified SET DEFAULT false;
+
+CREATE TABLE email_verification_tokens (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  token_hash VARCHAR NOT NULL UNIQUE,
+  expires_at TIMESTAMPTZ NOT NULL,
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now())
+);
+
+CREATE INDEX ON email_verification_tokens (user_id);
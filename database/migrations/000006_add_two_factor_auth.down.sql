@@ -0,0 +1,3 @@
+DROP TABLE mfa_tokens;
+DROP TABLE recovery_codes;
+DROP TABLE totp_secrets;
@@ -0,0 +1,19 @@
+CREATE TABLE login_attempts (
+  key VARCHAR PRIMARY KEY,
+  failures INT NOT NULL DEFAULT 0,
+  last_failure_at TIMESTAMPTZ NOT NULL,
+  locked_until TIMESTAMPTZ
+);
+
+CREATE TABLE audit_logs (
+  id UUID PRIMARY KEY,
+  user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  action VARCHAR NOT NULL,
+  username VARCHAR NOT NULL,
+  client_ip VARCHAR NOT NULL,
+  user_agent VARCHAR NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now())
+);
+
+CREATE INDEX ON audit_logs (user_id);
+CREATE INDEX ON audit_logs (created_at);
@@ -0,0 +1,14 @@
+CREATE TABLE personal_access_tokens (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR NOT NULL,
+  token_hash VARCHAR NOT NULL UNIQUE,
+  token_prefix VARCHAR NOT NULL,
+  scopes VARCHAR[] NOT NULL,
+  expires_at TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now())
+);
+
+CREATE INDEX ON personal_access_tokens (user_id);
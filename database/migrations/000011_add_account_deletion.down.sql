@@ -0,0 +1,6 @@
+ALTER TABLE posts DROP CONSTRAINT posts_user_id_fkey;
+ALTER TABLE posts
+ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+ALTER TABLE users DROP COLUMN purging_at;
+ALTER TABLE users DROP COLUMN deleted_at;
@@ -0,0 +1,10 @@
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ;
+-- set once the purger starts deleting the account's objects, from then on
+-- logging in can't restore it anymore
+ALTER TABLE users ADD COLUMN purging_at TIMESTAMPTZ;
+
+CREATE INDEX ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE posts DROP CONSTRAINT posts_user_id_fkey;
+ALTER TABLE posts
+ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
@@ -0,0 +1,15 @@
+CREATE TABLE data_exports (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  status VARCHAR NOT NULL DEFAULT 'pending',
+  object_key VARCHAR,
+  expires_at TIMESTAMPTZ,
+  completed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now())
+);
+
+CREATE INDEX ON data_exports (user_id);
+CREATE INDEX ON data_exports (status);
+
+-- a user can only have one export being built at a time
+CREATE UNIQUE INDEX ON data_exports (user_id) WHERE status = 'pending';
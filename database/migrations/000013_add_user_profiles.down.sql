@@ -0,0 +1,8 @@
+ALTER TABLE users
+DROP COLUMN display_name,
+DROP COLUMN bio,
+DROP COLUMN country_code,
+DROP COLUMN avatar_key,
+DROP COLUMN main_event,
+DROP COLUMN wca_id,
+DROP COLUMN social_links;
@@ -0,0 +1,8 @@
+ALTER TABLE users
+ADD COLUMN display_name VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN bio VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN country_code VARCHAR(2) NOT NULL DEFAULT '',
+ADD COLUMN avatar_key VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN main_event VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN wca_id VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN social_links VARCHAR[] NOT NULL DEFAULT '{}';
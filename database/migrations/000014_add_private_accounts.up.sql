@@ -0,0 +1,10 @@
+ALTER TABLE users ADD COLUMN is_private BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE follow_requests (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  requested_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now()),
+  PRIMARY KEY(user_id, requested_user_id)
+);
+
+CREATE INDEX ON follow_requests (requested_user_id);
@@ -0,0 +1,15 @@
+CREATE TABLE blocks (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  blocked_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now()),
+  PRIMARY KEY(user_id, blocked_user_id)
+);
+
+CREATE INDEX ON blocks (blocked_user_id);
+
+CREATE TABLE mutes (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  muted_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now()),
+  PRIMARY KEY(user_id, muted_user_id)
+);
@@ -0,0 +1,29 @@
+CREATE TABLE post_reactions (
+  post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  reaction VARCHAR NOT NULL CHECK (reaction IN ('like', 'cube', 'pb', 'dnf', 'plus_two')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now()),
+  PRIMARY KEY(post_id, user_id)
+);
+
+CREATE INDEX ON post_reactions (user_id);
+
+CREATE TABLE comment_reactions (
+  comment_id UUID NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  reaction VARCHAR NOT NULL CHECK (reaction IN ('like', 'cube', 'pb', 'dnf', 'plus_two')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now()),
+  PRIMARY KEY(comment_id, user_id)
+);
+
+CREATE INDEX ON comment_reactions (user_id);
+
+CREATE TABLE reply_reactions (
+  reply_id UUID NOT NULL REFERENCES replies(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  reaction VARCHAR NOT NULL CHECK (reaction IN ('like', 'cube', 'pb', 'dnf', 'plus_two')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now()),
+  PRIMARY KEY(reply_id, user_id)
+);
+
+CREATE INDEX ON reply_reactions (user_id);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS reply_revisions;
+DROP TABLE IF EXISTS comment_revisions;
+DROP TABLE IF EXISTS post_revisions;
+
+ALTER TABLE replies DROP COLUMN edited_at;
+ALTER TABLE comments DROP COLUMN edited_at;
+ALTER TABLE posts DROP COLUMN image_keys;
+ALTER TABLE posts DROP COLUMN edited_at;
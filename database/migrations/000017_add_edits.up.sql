@@ -0,0 +1,42 @@
+ALTER TABLE posts ADD COLUMN edited_at TIMESTAMPTZ;
+
+-- images used to be found by the post id and their index. edits upload new
+-- images under new keys instead of overwriting the old ones, which stay for
+-- the revisions, so every post lists the keys of its images
+ALTER TABLE posts ADD COLUMN image_keys VARCHAR[] NOT NULL DEFAULT '{}';
+
+UPDATE posts SET image_keys = ARRAY(
+  SELECT posts.id || '_' || i || '.jpg' FROM generate_series(0, posts.image_count - 1) AS i
+);
+
+ALTER TABLE comments ADD COLUMN edited_at TIMESTAMPTZ;
+ALTER TABLE replies ADD COLUMN edited_at TIMESTAMPTZ;
+
+CREATE TABLE post_revisions (
+  id UUID PRIMARY KEY,
+  post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  text_content VARCHAR NOT NULL,
+  image_count INT NOT NULL,
+  image_keys VARCHAR[] NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now())
+);
+
+CREATE INDEX ON post_revisions (post_id);
+
+CREATE TABLE comment_revisions (
+  id UUID PRIMARY KEY,
+  comment_id UUID NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+  content VARCHAR NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now())
+);
+
+CREATE INDEX ON comment_revisions (comment_id);
+
+CREATE TABLE reply_revisions (
+  id UUID PRIMARY KEY,
+  reply_id UUID NOT NULL REFERENCES replies(id) ON DELETE CASCADE,
+  content VARCHAR NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now())
+);
+
+CREATE INDEX ON reply_revisions (reply_id);
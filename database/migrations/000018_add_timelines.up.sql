@@ -0,0 +1,23 @@
+CREATE TABLE timelines (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY(user_id, post_id)
+);
+
+CREATE INDEX ON timelines (user_id, created_at DESC, post_id DESC);
+CREATE INDEX ON timelines (user_id, author_id);
+
+CREATE TABLE timeline_pull_authors (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(now())
+);
+
+INSERT INTO timelines(user_id, post_id, author_id, created_at)
+SELECT follows.user_id, posts.id, posts.user_id, posts.created_at
+FROM follows
+INNER JOIN posts ON posts.user_id = follows.followed_user_id;
+
+-- the feed reads the latest posts of every followed pull author on its own
+CREATE INDEX posts_user_id_created_at_idx ON posts (user_id, created_at DESC, id DESC);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS users_display_name_trgm_idx;
+DROP INDEX IF EXISTS users_username_trgm_idx;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector;
+
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- the vectors are stored so ranking a match doesn't parse the text again
+ALTER TABLE posts ADD COLUMN search_vector tsvector
+  GENERATED ALWAYS AS (to_tsvector('english', text_content)) STORED;
+ALTER TABLE comments ADD COLUMN search_vector tsvector
+  GENERATED ALWAYS AS (to_tsvector('english', content)) STORED;
+
+CREATE INDEX posts_search_vector_idx ON posts USING GIN (search_vector);
+CREATE INDEX comments_search_vector_idx ON comments USING GIN (search_vector);
+
+CREATE INDEX users_username_trgm_idx ON users USING GIN (username gin_trgm_ops);
+CREATE INDEX users_display_name_trgm_idx ON users USING GIN (display_name gin_trgm_ops);
@@ -0,0 +1,3 @@
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs(id, user_id, action, username, client_ip, user_agent)
+VALUES ($1, $2, $3, $4, $5, $6);
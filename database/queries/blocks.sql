@@ -0,0 +1,35 @@
+-- name: BlockUser :one
+INSERT INTO blocks(user_id, blocked_user_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UnblockUser :execrows
+DELETE FROM blocks WHERE user_id = $1 AND blocked_user_id = $2;
+
+-- name: GetBlockedUsers :many
+SELECT users.*, blocks.created_at AS blocked_at FROM blocks
+INNER JOIN users ON blocks.blocked_user_id = users.id
+WHERE blocks.user_id = $1
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (blocks.created_at, blocks.blocked_user_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY blocks.created_at DESC, blocks.blocked_user_id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: IsBlockedBetween :one
+SELECT EXISTS(
+  SELECT 1 FROM blocks
+  WHERE (user_id = $1 AND blocked_user_id = $2)
+  OR (user_id = $2 AND blocked_user_id = $1)
+);
+
+-- name: DeleteFollowsBetween :exec
+DELETE FROM follows
+WHERE (user_id = $1 AND followed_user_id = $2)
+OR (user_id = $2 AND followed_user_id = $1);
+
+-- name: DeleteFollowRequestsBetween :exec
+DELETE FROM follow_requests
+WHERE (user_id = $1 AND requested_user_id = $2)
+OR (user_id = $2 AND requested_user_id = $1);
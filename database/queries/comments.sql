@@ -9,9 +9,10 @@ DELETE FROM comments WHERE id = $1;
 -- name: GetCommentById :one
 SELECT comments.*, users.* , COUNT(replies.id) as number_of_replies
 FROM comments
-INNER JOIN users ON posts.user_id = users.id
+INNER JOIN users ON comments.user_id = users.id
 LEFT JOIN replies ON replies.comment_id = comments.id
 WHERE comments.id = $1
+GROUP BY comments.id, users.id
 LIMIT 1;
 
 -- name: GetCommentsByPost :many
@@ -23,7 +24,13 @@ FROM
   WHERE post_id = $1
   GROUP BY comments.id ) as c
 INNER JOIN users ON c.user_id = users.id
-ORDER BY number_of_replies DESC, c.created_at DESC
+WHERE sqlc.narg('cursor_number_of_replies')::bigint IS NULL
+OR (c.number_of_replies, c.created_at, c.id) < (
+  sqlc.narg('cursor_number_of_replies'),
+  sqlc.narg('cursor_created_at')::timestamptz,
+  sqlc.narg('cursor_id')::uuid
+)
+ORDER BY number_of_replies DESC, c.created_at DESC, c.id DESC
 LIMIT $2 OFFSET $3;
 
 -- name: PostReply :one
@@ -46,6 +53,32 @@ SELECT *
 FROM replies
 INNER JOIN users ON replies.user_id = users.id
 WHERE comment_id = $1
-ORDER BY replies.created_at ASC
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (replies.created_at, replies.id) > (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY replies.created_at ASC, replies.id ASC
 LIMIT $2 OFFSET $3;
 
+
+-- name: GetAllCommentsByUser :many
+SELECT * FROM comments
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetAllRepliesByUser :many
+SELECT * FROM replies
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: UpdateComment :one
+UPDATE comments
+SET content = $2, edited_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateReply :one
+UPDATE replies
+SET content = $2, edited_at = now()
+WHERE id = $1
+RETURNING *;
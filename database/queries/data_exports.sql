@@ -0,0 +1,35 @@
+-- name: CreateDataExport :one
+INSERT INTO data_exports(id, user_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetDataExportById :one
+SELECT * FROM data_exports WHERE id = $1 LIMIT 1;
+
+-- name: GetDataExportsByUser :many
+SELECT * FROM data_exports
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetPendingDataExports :many
+SELECT * FROM data_exports
+WHERE status = 'pending'
+ORDER BY created_at ASC;
+
+-- name: GetExpiredDataExports :many
+SELECT * FROM data_exports
+WHERE status = 'ready' AND expires_at < now();
+
+-- name: CompleteDataExport :one
+UPDATE data_exports
+SET status = 'ready', object_key = $2, expires_at = $3, completed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: FailDataExport :exec
+UPDATE data_exports
+SET status = 'failed', completed_at = now()
+WHERE id = $1;
+
+-- name: DeleteDataExport :exec
+DELETE FROM data_exports WHERE id = $1;
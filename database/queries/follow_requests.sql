@@ -0,0 +1,38 @@
+-- name: CreateFollowRequest :one
+INSERT INTO follow_requests(user_id, requested_user_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: DeleteFollowRequest :execrows
+DELETE FROM follow_requests WHERE user_id = $1 AND requested_user_id = $2;
+
+-- name: GetReceivedFollowRequests :many
+SELECT users.*, follow_requests.created_at AS requested_at FROM follow_requests
+INNER JOIN users ON follow_requests.user_id = users.id
+WHERE follow_requests.requested_user_id = $1
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (follow_requests.created_at, follow_requests.user_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY follow_requests.created_at DESC, follow_requests.user_id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetSentFollowRequests :many
+SELECT users.*, follow_requests.created_at AS requested_at FROM follow_requests
+INNER JOIN users ON follow_requests.requested_user_id = users.id
+WHERE follow_requests.user_id = $1
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (follow_requests.created_at, follow_requests.requested_user_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY follow_requests.created_at DESC, follow_requests.requested_user_id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ApproveAllFollowRequests :exec
+INSERT INTO follows(user_id, followed_user_id)
+SELECT user_id, requested_user_id FROM follow_requests
+WHERE requested_user_id = $1
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteFollowRequestsByUser :exec
+DELETE FROM follow_requests WHERE requested_user_id = $1;
@@ -4,17 +4,27 @@ VALUES ($1, $2)
 RETURNING *;
 
 -- name: GetFollowers :many
-SELECT following_users.* FROM follows
+SELECT following_users.*, follows.created_at AS followed_at FROM follows
 INNER JOIN users as followed_users on follows.followed_user_id = followed_users.id
 INNER JOIN users as following_users on follows.user_id = following_users.id
 WHERE follows.followed_user_id = $1
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (follows.created_at, follows.user_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY follows.created_at DESC, follows.user_id DESC
 LIMIT $2 OFFSET $3;
 
 -- name: GetFollowing :many
-SELECT followed_users.* FROM follows
+SELECT followed_users.*, follows.created_at AS followed_at FROM follows
 INNER JOIN users as followed_users on follows.followed_user_id = followed_users.id
 INNER JOIN users as following_users on follows.user_id = following_users.id
 WHERE follows.user_id = $1
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (follows.created_at, follows.followed_user_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY follows.created_at DESC, follows.followed_user_id DESC
 LIMIT $2 OFFSET $3;
 
 -- name: UnfollowUser :exec
@@ -26,3 +36,8 @@ SELECT count(user_id) FROM follows WHERE user_id = $1;
 -- name: GetFollowersCount :one
 SELECT count(followed_user_id) FROM follows WHERE followed_user_id = $1;
 
+
+-- name: IsFollowing :one
+SELECT EXISTS(
+  SELECT 1 FROM follows WHERE user_id = $1 AND followed_user_id = $2
+);
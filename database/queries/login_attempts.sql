@@ -0,0 +1,21 @@
+-- name: RecordLoginFailure :one
+INSERT INTO login_attempts(key, failures, last_failure_at)
+VALUES (@key, 1, @failed_at)
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE
+    WHEN login_attempts.last_failure_at < @reset_before THEN 1
+    ELSE login_attempts.failures + 1
+  END,
+  last_failure_at = EXCLUDED.last_failure_at
+RETURNING failures;
+
+-- name: GetLoginAttempt :one
+SELECT * FROM login_attempts WHERE key = $1 LIMIT 1;
+
+-- name: LockLoginAttempt :exec
+UPDATE login_attempts
+SET locked_until = $2
+WHERE key = $1;
+
+-- name: DeleteLoginAttempt :exec
+DELETE FROM login_attempts WHERE key = $1;
@@ -0,0 +1,57 @@
+-- name: SendMessage :one
+INSERT INTO messages(id, content, from_user_id, to_user_id)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetConversations :many
+SELECT users.*,
+  last_messages.id AS message_id,
+  last_messages.content,
+  last_messages.from_user_id,
+  last_messages.to_user_id,
+  last_messages.read_at,
+  last_messages.created_at AS message_created_at,
+  ( SELECT COUNT(*)
+  FROM messages AS unread_messages
+  WHERE unread_messages.from_user_id = users.id
+    AND unread_messages.to_user_id = @user_id
+    AND unread_messages.read_at IS NULL ) AS unread_count
+FROM 
+  ( SELECT DISTINCT ON (peer_id) m.*
+  FROM 
+    ( SELECT messages.*,
+      CASE WHEN messages.from_user_id = @user_id THEN messages.to_user_id ELSE messages.from_user_id END AS peer_id
+    FROM messages
+    WHERE messages.from_user_id = @user_id OR messages.to_user_id = @user_id ) as m
+  ORDER BY peer_id, m.created_at DESC ) as last_messages
+INNER JOIN users ON last_messages.peer_id = users.id
+WHERE (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (last_messages.created_at, last_messages.id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY last_messages.created_at DESC, last_messages.id DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetConversation :many
+SELECT *
+FROM messages
+WHERE (
+  (from_user_id = @user_id AND to_user_id = @peer_id)
+  OR (from_user_id = @peer_id AND to_user_id = @user_id)
+)
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (created_at, id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY created_at DESC, id DESC
+LIMIT $1 OFFSET $2;
+
+-- name: MarkConversationAsRead :exec
+UPDATE messages
+SET read_at = now()
+WHERE from_user_id = @peer_id AND to_user_id = @user_id AND read_at IS NULL;
+
+-- name: GetAllMessagesByUser :many
+SELECT * FROM messages
+WHERE from_user_id = $1 OR to_user_id = $1
+ORDER BY created_at ASC;
@@ -0,0 +1,18 @@
+-- name: MuteUser :one
+INSERT INTO mutes(user_id, muted_user_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UnmuteUser :execrows
+DELETE FROM mutes WHERE user_id = $1 AND muted_user_id = $2;
+
+-- name: GetMutedUsers :many
+SELECT users.*, mutes.created_at AS muted_at FROM mutes
+INNER JOIN users ON mutes.muted_user_id = users.id
+WHERE mutes.user_id = $1
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (mutes.created_at, mutes.muted_user_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY mutes.created_at DESC, mutes.muted_user_id DESC
+LIMIT $2 OFFSET $3;
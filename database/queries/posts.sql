@@ -1,6 +1,6 @@
 -- name: CreatePost :one
-INSERT INTO posts(id, text_content, image_count, user_id)
-VALUES ($1, $2, $3, $4)
+INSERT INTO posts(id, text_content, image_count, image_keys, user_id)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: GetPostById :one
@@ -15,29 +15,134 @@ SELECT *
 FROM posts
 INNER JOIN users ON posts.user_id = users.id
 WHERE user_id = $1
-ORDER BY posts.created_at DESC
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (posts.created_at, posts.id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY posts.created_at DESC, posts.id DESC
 LIMIT $2 OFFSET $3;
 
 -- name: GetFeed :many
-SELECT *
-FROM posts
-INNER JOIN users ON posts.user_id = users.id
-WHERE user_id IN (
-  SELECT followed_user.id
-  FROM follows
-  INNER JOIN users as followed_user ON followed_user.id = follows.followed_user_id
-  WHERE follows.user_id = $1
+SELECT posts.*, users.*
+FROM timelines
+INNER JOIN posts ON posts.id = timelines.post_id
+INNER JOIN users ON users.id = timelines.author_id
+WHERE timelines.user_id = $1
+AND users.deleted_at IS NULL
+AND timelines.author_id NOT IN (
+  SELECT muted_user_id FROM mutes WHERE mutes.user_id = $1
 )
-ORDER BY posts.created_at DESC
-LIMIT $2 OFFSET $3;
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (timelines.created_at, timelines.post_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY timelines.created_at DESC, timelines.post_id DESC
+LIMIT $2;
+
+-- name: GetPulledFeedPosts :many
+SELECT pulled_posts.*, users.*
+FROM follows
+INNER JOIN timeline_pull_authors ON timeline_pull_authors.user_id = follows.followed_user_id
+INNER JOIN users ON users.id = follows.followed_user_id
+CROSS JOIN LATERAL (
+  SELECT * FROM posts
+  WHERE posts.user_id = follows.followed_user_id
+  AND (
+    sqlc.narg('cursor_created_at')::timestamptz IS NULL
+    OR (posts.created_at, posts.id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+  )
+  ORDER BY posts.created_at DESC, posts.id DESC
+  LIMIT sqlc.arg('limit')
+) AS pulled_posts
+WHERE follows.user_id = sqlc.arg('user_id')
+AND users.deleted_at IS NULL
+AND follows.followed_user_id NOT IN (
+  SELECT muted_user_id FROM mutes WHERE mutes.user_id = sqlc.arg('user_id')
+)
+ORDER BY pulled_posts.created_at DESC, pulled_posts.id DESC
+LIMIT sqlc.arg('limit');
 
 -- name: GetGuestFeed :many
 SELECT *
 FROM posts
 INNER JOIN users ON posts.user_id = users.id
-ORDER BY posts.created_at DESC
+WHERE users.is_private = false
+AND users.deleted_at IS NULL
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (posts.created_at, posts.id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY posts.created_at DESC, posts.id DESC
 LIMIT $1 OFFSET $2;
 
 -- name: DeletePost :exec
 DELETE FROM posts WHERE id = $1;
 
+-- name: GetPostImageKeys :many
+SELECT unnest(image_keys)::varchar AS image_key FROM posts
+WHERE posts.id = $1
+UNION
+SELECT unnest(post_revisions.image_keys)::varchar FROM post_revisions
+WHERE post_revisions.post_id = $1;
+
+-- name: GetPostImageKeysByUser :many
+SELECT unnest(image_keys)::varchar AS image_key FROM posts
+WHERE posts.user_id = $1
+UNION
+SELECT unnest(post_revisions.image_keys)::varchar FROM post_revisions
+INNER JOIN posts ON post_revisions.post_id = posts.id
+WHERE posts.user_id = $1;
+
+-- name: GetAllPostsByUser :many
+SELECT * FROM posts
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: UpdatePost :one
+UPDATE posts
+SET text_content = $2, image_count = $3, image_keys = $4, edited_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetRankedFeedCandidates :many
+SELECT posts.*, users.*,
+  (
+    SELECT COUNT(*) FROM post_reactions
+    WHERE post_reactions.post_id = posts.id
+  ) AS reaction_count,
+  (
+    SELECT COUNT(*) FROM comments
+    WHERE comments.post_id = posts.id
+  ) AS comment_count,
+  ((
+    SELECT COUNT(*) FROM post_reactions
+    INNER JOIN posts AS reacted_posts ON post_reactions.post_id = reacted_posts.id
+    WHERE post_reactions.user_id = @viewer_id AND reacted_posts.user_id = posts.user_id
+  ) + (
+    SELECT COUNT(*) FROM comments
+    INNER JOIN posts AS commented_posts ON comments.post_id = commented_posts.id
+    WHERE comments.user_id = @viewer_id AND commented_posts.user_id = posts.user_id
+  ))::bigint AS affinity,
+  EXISTS(
+    SELECT 1 FROM follows
+    WHERE follows.user_id = @viewer_id AND follows.followed_user_id = posts.user_id
+  ) AS is_followed
+FROM posts
+INNER JOIN users ON posts.user_id = users.id
+WHERE posts.created_at > @since AND posts.created_at <= @until
+AND posts.user_id <> @viewer_id
+AND users.deleted_at IS NULL
+AND (
+  users.is_private = false
+  OR posts.user_id IN (SELECT followed_user_id FROM follows WHERE follows.user_id = @viewer_id)
+)
+AND posts.user_id NOT IN (
+  SELECT muted_user_id FROM mutes WHERE mutes.user_id = @viewer_id
+)
+AND NOT EXISTS (
+  SELECT 1 FROM blocks
+  WHERE (blocks.user_id = @viewer_id AND blocks.blocked_user_id = posts.user_id)
+  OR (blocks.user_id = posts.user_id AND blocks.blocked_user_id = @viewer_id)
+)
+ORDER BY posts.created_at DESC
+LIMIT @candidate_limit;
@@ -0,0 +1,92 @@
+-- name: SetPostReaction :one
+INSERT INTO post_reactions(post_id, user_id, reaction)
+VALUES ($1, $2, $3)
+ON CONFLICT (post_id, user_id) DO UPDATE
+SET reaction = EXCLUDED.reaction, created_at = now()
+RETURNING *;
+
+-- name: DeletePostReaction :execrows
+DELETE FROM post_reactions
+WHERE post_id = $1 AND user_id = $2 AND reaction = $3;
+
+-- name: GetPostReactionCounts :many
+SELECT post_id, reaction, COUNT(*) AS count FROM post_reactions
+WHERE post_id = ANY(@post_ids::uuid[])
+GROUP BY post_id, reaction;
+
+-- name: GetPostReactionsByUser :many
+SELECT post_id, reaction FROM post_reactions
+WHERE user_id = $1 AND post_id = ANY(@post_ids::uuid[]);
+
+-- name: GetPostReactionUsers :many
+SELECT post_reactions.reaction, post_reactions.created_at AS reacted_at, users.* FROM post_reactions
+INNER JOIN users ON post_reactions.user_id = users.id
+WHERE post_reactions.post_id = $1
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (post_reactions.created_at, post_reactions.user_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY post_reactions.created_at DESC, post_reactions.user_id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: SetCommentReaction :one
+INSERT INTO comment_reactions(comment_id, user_id, reaction)
+VALUES ($1, $2, $3)
+ON CONFLICT (comment_id, user_id) DO UPDATE
+SET reaction = EXCLUDED.reaction, created_at = now()
+RETURNING *;
+
+-- name: DeleteCommentReaction :execrows
+DELETE FROM comment_reactions
+WHERE comment_id = $1 AND user_id = $2 AND reaction = $3;
+
+-- name: GetCommentReactionCounts :many
+SELECT comment_id, reaction, COUNT(*) AS count FROM comment_reactions
+WHERE comment_id = ANY(@comment_ids::uuid[])
+GROUP BY comment_id, reaction;
+
+-- name: GetCommentReactionsByUser :many
+SELECT comment_id, reaction FROM comment_reactions
+WHERE user_id = $1 AND comment_id = ANY(@comment_ids::uuid[]);
+
+-- name: GetCommentReactionUsers :many
+SELECT comment_reactions.reaction, comment_reactions.created_at AS reacted_at, users.* FROM comment_reactions
+INNER JOIN users ON comment_reactions.user_id = users.id
+WHERE comment_reactions.comment_id = $1
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (comment_reactions.created_at, comment_reactions.user_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY comment_reactions.created_at DESC, comment_reactions.user_id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: SetReplyReaction :one
+INSERT INTO reply_reactions(reply_id, user_id, reaction)
+VALUES ($1, $2, $3)
+ON CONFLICT (reply_id, user_id) DO UPDATE
+SET reaction = EXCLUDED.reaction, created_at = now()
+RETURNING *;
+
+-- name: DeleteReplyReaction :execrows
+DELETE FROM reply_reactions
+WHERE reply_id = $1 AND user_id = $2 AND reaction = $3;
+
+-- name: GetReplyReactionCounts :many
+SELECT reply_id, reaction, COUNT(*) AS count FROM reply_reactions
+WHERE reply_id = ANY(@reply_ids::uuid[])
+GROUP BY reply_id, reaction;
+
+-- name: GetReplyReactionsByUser :many
+SELECT reply_id, reaction FROM reply_reactions
+WHERE user_id = $1 AND reply_id = ANY(@reply_ids::uuid[]);
+
+-- name: GetReplyReactionUsers :many
+SELECT reply_reactions.reaction, reply_reactions.created_at AS reacted_at, users.* FROM reply_reactions
+INNER JOIN users ON reply_reactions.user_id = users.id
+WHERE reply_reactions.reply_id = $1
+AND (
+  sqlc.narg('cursor_created_at')::timestamptz IS NULL
+  OR (reply_reactions.created_at, reply_reactions.user_id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY reply_reactions.created_at DESC, reply_reactions.user_id DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,29 @@
+-- name: CreatePostRevision :one
+INSERT INTO post_revisions(id, post_id, text_content, image_count, image_keys)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetPostRevisions :many
+SELECT * FROM post_revisions
+WHERE post_id = $1
+ORDER BY created_at DESC;
+
+-- name: CreateCommentRevision :one
+INSERT INTO comment_revisions(id, comment_id, content)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetCommentRevisions :many
+SELECT * FROM comment_revisions
+WHERE comment_id = $1
+ORDER BY created_at DESC;
+
+-- name: CreateReplyRevision :one
+INSERT INTO reply_revisions(id, reply_id, content)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetReplyRevisions :many
+SELECT * FROM reply_revisions
+WHERE reply_id = $1
+ORDER BY created_at DESC;
@@ -0,0 +1,89 @@
+-- name: SearchPosts :many
+SELECT posts.*, users.*,
+  ts_rank(posts.search_vector, websearch_to_tsquery('english', @query)) AS rank,
+  ts_headline(
+    'english', posts.text_content, websearch_to_tsquery('english', @query),
+    'StartSel=<mark>, StopSel=</mark>, MinWords=10, MaxWords=30'
+  )::text AS snippet
+FROM posts
+INNER JOIN users ON posts.user_id = users.id
+WHERE posts.search_vector @@ websearch_to_tsquery('english', @query)
+AND users.deleted_at IS NULL
+AND (
+  users.is_private = false
+  OR users.id = @viewer_id
+  OR EXISTS (
+    SELECT 1 FROM follows
+    WHERE follows.user_id = @viewer_id AND follows.followed_user_id = users.id
+  )
+)
+AND NOT EXISTS (
+  SELECT 1 FROM blocks
+  WHERE (blocks.user_id = @viewer_id AND blocks.blocked_user_id = users.id)
+  OR (blocks.user_id = users.id AND blocks.blocked_user_id = @viewer_id)
+)
+AND (
+  sqlc.narg('cursor_rank')::real IS NULL
+  OR (ts_rank(posts.search_vector, websearch_to_tsquery('english', @query)), posts.created_at, posts.id)
+    < (sqlc.narg('cursor_rank')::real, sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY rank DESC, posts.created_at DESC, posts.id DESC
+LIMIT $1;
+
+-- name: SearchComments :many
+SELECT comments.*, users.*,
+  (SELECT COUNT(*) FROM replies WHERE replies.comment_id = comments.id) AS number_of_replies,
+  ts_rank(comments.search_vector, websearch_to_tsquery('english', @query)) AS rank,
+  ts_headline(
+    'english', comments.content, websearch_to_tsquery('english', @query),
+    'StartSel=<mark>, StopSel=</mark>, MinWords=10, MaxWords=30'
+  )::text AS snippet
+FROM comments
+INNER JOIN users ON comments.user_id = users.id
+INNER JOIN posts ON comments.post_id = posts.id
+INNER JOIN users AS post_owners ON posts.user_id = post_owners.id
+WHERE comments.search_vector @@ websearch_to_tsquery('english', @query)
+AND users.deleted_at IS NULL
+AND post_owners.deleted_at IS NULL
+AND (
+  post_owners.is_private = false
+  OR post_owners.id = @viewer_id
+  OR EXISTS (
+    SELECT 1 FROM follows
+    WHERE follows.user_id = @viewer_id AND follows.followed_user_id = post_owners.id
+  )
+)
+AND NOT EXISTS (
+  SELECT 1 FROM blocks
+  WHERE (blocks.user_id = @viewer_id AND blocks.blocked_user_id IN (users.id, post_owners.id))
+  OR (blocks.user_id IN (users.id, post_owners.id) AND blocks.blocked_user_id = @viewer_id)
+)
+AND (
+  sqlc.narg('cursor_rank')::real IS NULL
+  OR (ts_rank(comments.search_vector, websearch_to_tsquery('english', @query)), comments.created_at, comments.id)
+    < (sqlc.narg('cursor_rank')::real, sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid)
+)
+ORDER BY rank DESC, comments.created_at DESC, comments.id DESC
+LIMIT $1;
+
+-- name: SearchUsers :many
+SELECT *,
+  GREATEST(similarity(username, @query), similarity(display_name, @query))::real AS rank
+FROM users
+WHERE (username % @query OR display_name % @query)
+AND deleted_at IS NULL
+AND NOT EXISTS (
+  SELECT 1 FROM blocks
+  WHERE (blocks.user_id = @viewer_id AND blocks.blocked_user_id = users.id)
+  OR (blocks.user_id = users.id AND blocks.blocked_user_id = @viewer_id)
+)
+AND (
+  sqlc.narg('cursor_rank')::real IS NULL
+  OR GREATEST(similarity(username, @query), similarity(display_name, @query))::real < sqlc.narg('cursor_rank')::real
+  OR (
+    GREATEST(similarity(username, @query), similarity(display_name, @query))::real = sqlc.narg('cursor_rank')::real
+    AND username > sqlc.narg('cursor_username')::varchar
+  )
+)
+ORDER BY rank DESC, username ASC
+LIMIT $1;
@@ -1,7 +1,42 @@
 -- name: CreateSession :one
-INSERT INTO sessions(id, user_id, refresh_token, client_ip, is_blocked, expires_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO sessions(id, user_id, refresh_token, client_ip, is_blocked, expires_at, family_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: GetSessionById :one
 SELECT * FROM sessions WHERE id = $1 LIMIT 1;
+
+-- name: GetActiveSessionsByUser :many
+SELECT * FROM sessions
+WHERE user_id = $1 AND is_blocked = false AND is_used = false AND expires_at > now()
+ORDER BY created_at DESC;
+
+-- name: MarkSessionAsUsed :execrows
+UPDATE sessions
+SET is_used = true
+WHERE id = $1 AND is_used = false;
+
+-- name: BlockSession :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1 AND user_id = $2;
+
+-- name: BlockSessionFamily :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1;
+
+-- name: BlockUserSessions :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE user_id = $1;
+
+-- name: BlockOtherUserSessions :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE user_id = $1 AND id <> $2;
+
+-- name: GetAllSessionsByUser :many
+SELECT * FROM sessions
+WHERE user_id = $1
+ORDER BY created_at DESC;
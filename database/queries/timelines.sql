@@ -0,0 +1,51 @@
+-- name: FanOutPost :exec
+INSERT INTO timelines(user_id, post_id, author_id, created_at)
+SELECT follows.user_id, posts.id, posts.user_id, posts.created_at
+FROM posts
+INNER JOIN follows ON follows.followed_user_id = posts.user_id
+WHERE posts.id = $1
+ON CONFLICT DO NOTHING;
+
+-- name: BackfillTimeline :exec
+INSERT INTO timelines(user_id, post_id, author_id, created_at)
+SELECT follows.user_id, posts.id, posts.user_id, posts.created_at
+FROM follows
+INNER JOIN posts ON posts.user_id = follows.followed_user_id
+WHERE follows.user_id = $1 AND follows.followed_user_id = $2
+ORDER BY posts.created_at DESC
+LIMIT $3
+ON CONFLICT DO NOTHING;
+
+-- name: BackfillFollowersTimelines :exec
+INSERT INTO timelines(user_id, post_id, author_id, created_at)
+SELECT follows.user_id, latest_posts.id, latest_posts.user_id, latest_posts.created_at
+FROM follows
+CROSS JOIN (
+  SELECT posts.id, posts.user_id, posts.created_at FROM posts
+  WHERE posts.user_id = @followed_user_id
+  ORDER BY posts.created_at DESC
+  LIMIT sqlc.arg('limit')
+) AS latest_posts
+WHERE follows.followed_user_id = @followed_user_id
+AND NOT EXISTS (
+  SELECT 1 FROM timeline_pull_authors WHERE timeline_pull_authors.user_id = @followed_user_id
+)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveAuthorFromTimeline :exec
+DELETE FROM timelines WHERE user_id = $1 AND author_id = $2;
+
+-- name: RemoveAuthorsFromTimelinesBetween :exec
+DELETE FROM timelines
+WHERE (user_id = $1 AND author_id = $2)
+OR (user_id = $2 AND author_id = $1);
+
+-- name: AddTimelinePullAuthor :exec
+INSERT INTO timeline_pull_authors(user_id)
+VALUES ($1)
+ON CONFLICT DO NOTHING;
+
+-- name: IsTimelinePullAuthor :one
+SELECT EXISTS(
+  SELECT 1 FROM timeline_pull_authors WHERE user_id = $1
+);
@@ -0,0 +1,47 @@
+-- name: UpsertTotpSecret :one
+INSERT INTO totp_secrets(user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, is_enabled = false, last_used_counter = 0
+RETURNING *;
+
+-- name: GetTotpSecretByUser :one
+SELECT * FROM totp_secrets WHERE user_id = $1 LIMIT 1;
+
+-- name: EnableTotp :exec
+UPDATE totp_secrets
+SET is_enabled = true
+WHERE user_id = $1;
+
+-- name: UseTotpCounter :execrows
+UPDATE totp_secrets
+SET last_used_counter = $2
+WHERE user_id = $1 AND last_used_counter < $2;
+
+-- name: DeleteTotpSecret :exec
+DELETE FROM totp_secrets WHERE user_id = $1;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO recovery_codes(id, user_id, code_hash)
+VALUES ($1, $2, $3);
+
+-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes
+SET used_at = now()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes WHERE user_id = $1;
+
+-- name: CreateMfaToken :one
+INSERT INTO mfa_tokens(id, user_id, token_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetMfaTokenByHash :one
+SELECT * FROM mfa_tokens WHERE token_hash = $1 LIMIT 1;
+
+-- name: UseMfaToken :execrows
+UPDATE mfa_tokens
+SET used_at = now()
+WHERE id = $1 AND used_at IS NULL;
@@ -9,9 +9,18 @@ SELECT * FROM users WHERE id = $1 LIMIT 1;
 -- name: GetUserByUsername :one
 SELECT * FROM users WHERE username = $1 LIMIT 1;
 
+-- name: GetUserByEmail :one
+SELECT * FROM users WHERE email = $1 LIMIT 1;
+
 -- name: GetUsersByUsername :many
 SELECT * FROM users
-WHERE username LIKE @input
+WHERE username LIKE @input AND deleted_at IS NULL
+AND NOT EXISTS (
+  SELECT 1 FROM blocks
+  WHERE (blocks.user_id = @viewer_id AND blocks.blocked_user_id = users.id)
+  OR (blocks.user_id = users.id AND blocks.blocked_user_id = @viewer_id)
+)
+AND (sqlc.narg('cursor_username')::varchar IS NULL OR username > sqlc.narg('cursor_username'))
 ORDER BY username ASC
 LIMIT $1 OFFSET $2;
 
@@ -29,7 +38,60 @@ RETURNING *;
 
 -- name: UpdateUsersPassword :one
 UPDATE users
-SET password_hash = $1
+SET password_hash = $1, password_changed_at = now()
 WHERE id = $2
 RETURNING *;
 
+-- name: UpdateUsersRole :one
+UPDATE users
+SET role = $1
+WHERE id = $2
+RETURNING *;
+
+-- name: VerifyUsersEmail :one
+UPDATE users
+SET is_verified = true
+WHERE id = $1
+RETURNING *;
+
+-- name: SoftDeleteUser :exec
+UPDATE users
+SET deleted_at = now()
+WHERE id = $1;
+
+-- name: RestoreUser :one
+UPDATE users
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at > @deleted_after AND purging_at IS NULL
+RETURNING *;
+
+-- name: GetUsersDeletedBefore :many
+SELECT id FROM users
+WHERE deleted_at < @deleted_before;
+
+-- name: ClaimUserPurge :execrows
+UPDATE users
+SET purging_at = now()
+WHERE id = $1 AND deleted_at < $2;
+
+-- name: PurgeUser :execrows
+DELETE FROM users
+WHERE id = $1 AND purging_at IS NOT NULL;
+
+-- name: UpdateUsersProfile :one
+UPDATE users
+SET display_name = $1, bio = $2, country_code = $3, main_event = $4, wca_id = $5, social_links = $6
+WHERE id = $7
+RETURNING *;
+
+-- name: UpdateUsersAvatar :one
+UPDATE users
+SET avatar_key = $1
+WHERE id = $2
+RETURNING *;
+
+-- name: UpdateUsersPrivacy :one
+UPDATE users
+SET is_private = $1
+WHERE id = $2
+RETURNING *;
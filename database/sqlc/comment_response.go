@@ -7,18 +7,35 @@ import (
 )
 
 type CommentResponse struct {
-	ID              uuid.UUID    `json:"id"`
-	Content         string       `json:"content"`
-	User            UserResponse `json:"user"`
-	NumberOfReplies int64        `json:"number_of_replies"`
-	CreatedAt       time.Time    `json:"created_at"`
+	ID              uuid.UUID        `json:"id"`
+	Content         string           `json:"content"`
+	User            UserResponse     `json:"user"`
+	NumberOfReplies int64            `json:"number_of_replies"`
+	CreatedAt       time.Time        `json:"created_at"`
+	EditedAt        *time.Time       `json:"edited_at"`
+	ReactionCounts  map[string]int64 `json:"reaction_counts"`
+	ReactedByMe     bool             `json:"reacted_by_me"`
+	MyReaction      string           `json:"my_reaction"`
 }
 
 func (comment GetCommentByIdRow) MakeResponse() CommentResponse {
 	user := UserResponse{
-		ID:        comment.UserID,
-		Username:  comment.Username,
-		CreatedAt: comment.CreatedAt_2,
+		ID:          comment.UserID,
+		Username:    comment.Username,
+		CreatedAt:   comment.CreatedAt_2,
+		DisplayName: comment.DisplayName,
+		Bio:         comment.Bio,
+		CountryCode: comment.CountryCode,
+		AvatarKey:   comment.AvatarKey,
+		MainEvent:   comment.MainEvent,
+		WcaID:       comment.WcaID,
+		SocialLinks: comment.SocialLinks,
+		IsPrivate:   comment.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if comment.EditedAt.Valid {
+		editedAt = &comment.EditedAt.Time
 	}
 
 	return CommentResponse{
@@ -26,15 +43,30 @@ func (comment GetCommentByIdRow) MakeResponse() CommentResponse {
 		Content:         comment.Content,
 		NumberOfReplies: comment.NumberOfReplies,
 		CreatedAt:       comment.CreatedAt,
+		EditedAt:        editedAt,
 		User:            user,
+		ReactionCounts:  map[string]int64{},
 	}
 }
 
 func (comment GetCommentsByPostRow) MakeResponse() CommentResponse {
 	user := UserResponse{
-		ID:        comment.UserID,
-		Username:  comment.Username,
-		CreatedAt: comment.CreatedAt_2,
+		ID:          comment.UserID,
+		Username:    comment.Username,
+		CreatedAt:   comment.CreatedAt_2,
+		DisplayName: comment.DisplayName,
+		Bio:         comment.Bio,
+		CountryCode: comment.CountryCode,
+		AvatarKey:   comment.AvatarKey,
+		MainEvent:   comment.MainEvent,
+		WcaID:       comment.WcaID,
+		SocialLinks: comment.SocialLinks,
+		IsPrivate:   comment.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if comment.EditedAt.Valid {
+		editedAt = &comment.EditedAt.Time
 	}
 
 	return CommentResponse{
@@ -42,43 +74,79 @@ func (comment GetCommentsByPostRow) MakeResponse() CommentResponse {
 		Content:         comment.Content,
 		NumberOfReplies: comment.NumberOfReplies,
 		CreatedAt:       comment.CreatedAt,
+		EditedAt:        editedAt,
 		User:            user,
+		ReactionCounts:  map[string]int64{},
 	}
 }
 
 type ReplyResponse struct {
-	ID        uuid.UUID    `json:"id"`
-	Content   string       `json:"content"`
-	User      UserResponse `json:"user"`
-	CreatedAt time.Time    `json:"created_at"`
+	ID             uuid.UUID        `json:"id"`
+	Content        string           `json:"content"`
+	User           UserResponse     `json:"user"`
+	CreatedAt      time.Time        `json:"created_at"`
+	EditedAt       *time.Time       `json:"edited_at"`
+	ReactionCounts map[string]int64 `json:"reaction_counts"`
+	ReactedByMe    bool             `json:"reacted_by_me"`
+	MyReaction     string           `json:"my_reaction"`
 }
 
 func (reply GetReplyByIdRow) MakeResponse() ReplyResponse {
 	user := UserResponse{
-		ID:        reply.UserID,
-		Username:  reply.Username,
-		CreatedAt: reply.CreatedAt_2,
+		ID:          reply.UserID,
+		Username:    reply.Username,
+		CreatedAt:   reply.CreatedAt_2,
+		DisplayName: reply.DisplayName,
+		Bio:         reply.Bio,
+		CountryCode: reply.CountryCode,
+		AvatarKey:   reply.AvatarKey,
+		MainEvent:   reply.MainEvent,
+		WcaID:       reply.WcaID,
+		SocialLinks: reply.SocialLinks,
+		IsPrivate:   reply.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if reply.EditedAt.Valid {
+		editedAt = &reply.EditedAt.Time
 	}
 
 	return ReplyResponse{
-		ID:        reply.ID,
-		Content:   reply.Content,
-		CreatedAt: reply.CreatedAt,
-		User:      user,
+		ID:             reply.ID,
+		Content:        reply.Content,
+		CreatedAt:      reply.CreatedAt,
+		EditedAt:       editedAt,
+		User:           user,
+		ReactionCounts: map[string]int64{},
 	}
 }
 
 func (reply GetRepliesByCommentRow) MakeResponse() ReplyResponse {
 	user := UserResponse{
-		ID:        reply.UserID,
-		Username:  reply.Username,
-		CreatedAt: reply.CreatedAt_2,
+		ID:          reply.UserID,
+		Username:    reply.Username,
+		CreatedAt:   reply.CreatedAt_2,
+		DisplayName: reply.DisplayName,
+		Bio:         reply.Bio,
+		CountryCode: reply.CountryCode,
+		AvatarKey:   reply.AvatarKey,
+		MainEvent:   reply.MainEvent,
+		WcaID:       reply.WcaID,
+		SocialLinks: reply.SocialLinks,
+		IsPrivate:   reply.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if reply.EditedAt.Valid {
+		editedAt = &reply.EditedAt.Time
 	}
 
 	return ReplyResponse{
-		ID:        reply.ID,
-		Content:   reply.Content,
-		CreatedAt: reply.CreatedAt,
-		User:      user,
+		ID:             reply.ID,
+		Content:        reply.Content,
+		CreatedAt:      reply.CreatedAt,
+		EditedAt:       editedAt,
+		User:           user,
+		ReactionCounts: map[string]int64{},
 	}
 }
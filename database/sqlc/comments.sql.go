@@ -7,9 +7,11 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const deleteComment = `-- name: DeleteComment :exec
@@ -30,27 +32,116 @@ func (q *Queries) DeleteReply(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const getAllCommentsByUser = `-- name: GetAllCommentsByUser :many
+SELECT id, content, user_id, post_id, created_at, edited_at, search_vector FROM comments
+WHERE user_id = $1
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetAllCommentsByUser(ctx context.Context, userID uuid.UUID) ([]Comment, error) {
+	rows, err := q.db.QueryContext(ctx, getAllCommentsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Comment{}
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.Content,
+			&i.UserID,
+			&i.PostID,
+			&i.CreatedAt,
+			&i.EditedAt,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllRepliesByUser = `-- name: GetAllRepliesByUser :many
+SELECT id, content, user_id, comment_id, created_at, edited_at FROM replies
+WHERE user_id = $1
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetAllRepliesByUser(ctx context.Context, userID uuid.UUID) ([]Reply, error) {
+	rows, err := q.db.QueryContext(ctx, getAllRepliesByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Reply{}
+	for rows.Next() {
+		var i Reply
+		if err := rows.Scan(
+			&i.ID,
+			&i.Content,
+			&i.UserID,
+			&i.CommentID,
+			&i.CreatedAt,
+			&i.EditedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCommentById = `-- name: GetCommentById :one
-SELECT comments.id, comments.content, comments.user_id, comments.post_id, comments.created_at, users.id, users.username, users.password_hash, users.email, users.created_at , COUNT(replies.id) as number_of_replies
+SELECT comments.id, comments.content, comments.user_id, comments.post_id, comments.created_at, comments.edited_at, comments.search_vector, users.id, users.username, users.password_hash, users.email, users.created_at, users.is_verified, users.password_changed_at, users.role, users.deleted_at, users.purging_at, users.display_name, users.bio, users.country_code, users.avatar_key, users.main_event, users.wca_id, users.social_links, users.is_private , COUNT(replies.id) as number_of_replies
 FROM comments
-INNER JOIN users ON posts.user_id = users.id
+INNER JOIN users ON comments.user_id = users.id
 LEFT JOIN replies ON replies.comment_id = comments.id
 WHERE comments.id = $1
+GROUP BY comments.id, users.id
 LIMIT 1
 `
 
 type GetCommentByIdRow struct {
-	ID              uuid.UUID `json:"id"`
-	Content         string    `json:"content"`
-	UserID          uuid.UUID `json:"user_id"`
-	PostID          uuid.UUID `json:"post_id"`
-	CreatedAt       time.Time `json:"created_at"`
-	ID_2            uuid.UUID `json:"id_2"`
-	Username        string    `json:"username"`
-	PasswordHash    string    `json:"password_hash"`
-	Email           string    `json:"email"`
-	CreatedAt_2     time.Time `json:"created_at_2"`
-	NumberOfReplies int64     `json:"number_of_replies"`
+	ID                uuid.UUID    `json:"id"`
+	Content           string       `json:"content"`
+	UserID            uuid.UUID    `json:"user_id"`
+	PostID            uuid.UUID    `json:"post_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	SearchVector      string       `json:"-"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
+	NumberOfReplies   int64        `json:"number_of_replies"`
 }
 
 func (q *Queries) GetCommentById(ctx context.Context, id uuid.UUID) (GetCommentByIdRow, error) {
@@ -62,51 +153,97 @@ func (q *Queries) GetCommentById(ctx context.Context, id uuid.UUID) (GetCommentB
 		&i.UserID,
 		&i.PostID,
 		&i.CreatedAt,
+		&i.EditedAt,
+		&i.SearchVector,
 		&i.ID_2,
 		&i.Username,
 		&i.PasswordHash,
 		&i.Email,
 		&i.CreatedAt_2,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
 		&i.NumberOfReplies,
 	)
 	return i, err
 }
 
 const getCommentsByPost = `-- name: GetCommentsByPost :many
-SELECT c.id, content, user_id, post_id, c.created_at, number_of_replies, users.id, username, password_hash, email, users.created_at
+SELECT c.id, content, user_id, post_id, c.created_at, edited_at, search_vector, number_of_replies, users.id, username, password_hash, email, users.created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 FROM 
-  ( SELECT comments.id, comments.content, comments.user_id, comments.post_id, comments.created_at, COUNT(replies.id) as number_of_replies
+  ( SELECT comments.id, comments.content, comments.user_id, comments.post_id, comments.created_at, comments.edited_at, comments.search_vector, COUNT(replies.id) as number_of_replies
   FROM comments
   LEFT JOIN replies ON replies.comment_id = comments.id
   WHERE post_id = $1
   GROUP BY comments.id ) as c
 INNER JOIN users ON c.user_id = users.id
-ORDER BY number_of_replies DESC, c.created_at DESC
+WHERE $4::bigint IS NULL
+OR (c.number_of_replies, c.created_at, c.id) < (
+  $4,
+  $5::timestamptz,
+  $6::uuid
+)
+ORDER BY number_of_replies DESC, c.created_at DESC, c.id DESC
 LIMIT $2 OFFSET $3
 `
 
 type GetCommentsByPostParams struct {
-	PostID uuid.UUID `json:"post_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+	PostID                uuid.UUID     `json:"post_id"`
+	Limit                 int32         `json:"limit"`
+	Offset                int32         `json:"offset"`
+	CursorNumberOfReplies sql.NullInt64 `json:"cursor_number_of_replies"`
+	CursorCreatedAt       sql.NullTime  `json:"cursor_created_at"`
+	CursorID              uuid.NullUUID `json:"cursor_id"`
 }
 
 type GetCommentsByPostRow struct {
-	ID              uuid.UUID `json:"id"`
-	Content         string    `json:"content"`
-	UserID          uuid.UUID `json:"user_id"`
-	PostID          uuid.UUID `json:"post_id"`
-	CreatedAt       time.Time `json:"created_at"`
-	NumberOfReplies int64     `json:"number_of_replies"`
-	ID_2            uuid.UUID `json:"id_2"`
-	Username        string    `json:"username"`
-	PasswordHash    string    `json:"password_hash"`
-	Email           string    `json:"email"`
-	CreatedAt_2     time.Time `json:"created_at_2"`
+	ID                uuid.UUID    `json:"id"`
+	Content           string       `json:"content"`
+	UserID            uuid.UUID    `json:"user_id"`
+	PostID            uuid.UUID    `json:"post_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	SearchVector      string       `json:"-"`
+	NumberOfReplies   int64        `json:"number_of_replies"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
 }
 
 func (q *Queries) GetCommentsByPost(ctx context.Context, arg GetCommentsByPostParams) ([]GetCommentsByPostRow, error) {
-	rows, err := q.db.QueryContext(ctx, getCommentsByPost, arg.PostID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getCommentsByPost,
+		arg.PostID,
+		arg.Limit,
+		arg.Offset,
+		arg.CursorNumberOfReplies,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -120,12 +257,27 @@ func (q *Queries) GetCommentsByPost(ctx context.Context, arg GetCommentsByPostPa
 			&i.UserID,
 			&i.PostID,
 			&i.CreatedAt,
+			&i.EditedAt,
+			&i.SearchVector,
 			&i.NumberOfReplies,
 			&i.ID_2,
 			&i.Username,
 			&i.PasswordHash,
 			&i.Email,
 			&i.CreatedAt_2,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
 		); err != nil {
 			return nil, err
 		}
@@ -141,35 +293,61 @@ func (q *Queries) GetCommentsByPost(ctx context.Context, arg GetCommentsByPostPa
 }
 
 const getRepliesByComment = `-- name: GetRepliesByComment :many
-SELECT replies.id, content, user_id, comment_id, replies.created_at, users.id, username, password_hash, email, users.created_at
+SELECT replies.id, content, user_id, comment_id, replies.created_at, edited_at, users.id, username, password_hash, email, users.created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 FROM replies
 INNER JOIN users ON replies.user_id = users.id
 WHERE comment_id = $1
-ORDER BY replies.created_at ASC
+AND (
+  $4::timestamptz IS NULL
+  OR (replies.created_at, replies.id) > ($4, $5::uuid)
+)
+ORDER BY replies.created_at ASC, replies.id ASC
 LIMIT $2 OFFSET $3
 `
 
 type GetRepliesByCommentParams struct {
-	CommentID uuid.UUID `json:"comment_id"`
-	Limit     int32     `json:"limit"`
-	Offset    int32     `json:"offset"`
+	CommentID       uuid.UUID     `json:"comment_id"`
+	Limit           int32         `json:"limit"`
+	Offset          int32         `json:"offset"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
 }
 
 type GetRepliesByCommentRow struct {
-	ID           uuid.UUID `json:"id"`
-	Content      string    `json:"content"`
-	UserID       uuid.UUID `json:"user_id"`
-	CommentID    uuid.UUID `json:"comment_id"`
-	CreatedAt    time.Time `json:"created_at"`
-	ID_2         uuid.UUID `json:"id_2"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"password_hash"`
-	Email        string    `json:"email"`
-	CreatedAt_2  time.Time `json:"created_at_2"`
+	ID                uuid.UUID    `json:"id"`
+	Content           string       `json:"content"`
+	UserID            uuid.UUID    `json:"user_id"`
+	CommentID         uuid.UUID    `json:"comment_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
 }
 
 func (q *Queries) GetRepliesByComment(ctx context.Context, arg GetRepliesByCommentParams) ([]GetRepliesByCommentRow, error) {
-	rows, err := q.db.QueryContext(ctx, getRepliesByComment, arg.CommentID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getRepliesByComment,
+		arg.CommentID,
+		arg.Limit,
+		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -183,11 +361,25 @@ func (q *Queries) GetRepliesByComment(ctx context.Context, arg GetRepliesByComme
 			&i.UserID,
 			&i.CommentID,
 			&i.CreatedAt,
+			&i.EditedAt,
 			&i.ID_2,
 			&i.Username,
 			&i.PasswordHash,
 			&i.Email,
 			&i.CreatedAt_2,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
 		); err != nil {
 			return nil, err
 		}
@@ -203,7 +395,7 @@ func (q *Queries) GetRepliesByComment(ctx context.Context, arg GetRepliesByComme
 }
 
 const getReplyById = `-- name: GetReplyById :one
-SELECT replies.id, content, user_id, comment_id, replies.created_at, users.id, username, password_hash, email, users.created_at
+SELECT replies.id, content, user_id, comment_id, replies.created_at, edited_at, users.id, username, password_hash, email, users.created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 FROM replies
 INNER JOIN users ON replies.user_id = users.id
 WHERE replies.id = $1
@@ -211,16 +403,30 @@ LIMIT 1
 `
 
 type GetReplyByIdRow struct {
-	ID           uuid.UUID `json:"id"`
-	Content      string    `json:"content"`
-	UserID       uuid.UUID `json:"user_id"`
-	CommentID    uuid.UUID `json:"comment_id"`
-	CreatedAt    time.Time `json:"created_at"`
-	ID_2         uuid.UUID `json:"id_2"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"password_hash"`
-	Email        string    `json:"email"`
-	CreatedAt_2  time.Time `json:"created_at_2"`
+	ID                uuid.UUID    `json:"id"`
+	Content           string       `json:"content"`
+	UserID            uuid.UUID    `json:"user_id"`
+	CommentID         uuid.UUID    `json:"comment_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
 }
 
 func (q *Queries) GetReplyById(ctx context.Context, id uuid.UUID) (GetReplyByIdRow, error) {
@@ -232,11 +438,25 @@ func (q *Queries) GetReplyById(ctx context.Context, id uuid.UUID) (GetReplyByIdR
 		&i.UserID,
 		&i.CommentID,
 		&i.CreatedAt,
+		&i.EditedAt,
 		&i.ID_2,
 		&i.Username,
 		&i.PasswordHash,
 		&i.Email,
 		&i.CreatedAt_2,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
 	)
 	return i, err
 }
@@ -244,7 +464,7 @@ func (q *Queries) GetReplyById(ctx context.Context, id uuid.UUID) (GetReplyByIdR
 const postComment = `-- name: PostComment :one
 INSERT INTO comments(id, content, user_id, post_id)
 VALUES ($1, $2, $3, $4)
-RETURNING id, content, user_id, post_id, created_at
+RETURNING id, content, user_id, post_id, created_at, edited_at, search_vector
 `
 
 type PostCommentParams struct {
@@ -268,6 +488,8 @@ func (q *Queries) PostComment(ctx context.Context, arg PostCommentParams) (Comme
 		&i.UserID,
 		&i.PostID,
 		&i.CreatedAt,
+		&i.EditedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
@@ -275,7 +497,7 @@ func (q *Queries) PostComment(ctx context.Context, arg PostCommentParams) (Comme
 const postReply = `-- name: PostReply :one
 INSERT INTO replies(id, content, user_id, comment_id)
 VALUES ($1, $2, $3, $4)
-RETURNING id, content, user_id, comment_id, created_at
+RETURNING id, content, user_id, comment_id, created_at, edited_at
 `
 
 type PostReplyParams struct {
@@ -299,6 +521,60 @@ func (q *Queries) PostReply(ctx context.Context, arg PostReplyParams) (Reply, er
 		&i.UserID,
 		&i.CommentID,
 		&i.CreatedAt,
+		&i.EditedAt,
+	)
+	return i, err
+}
+
+const updateComment = `-- name: UpdateComment :one
+UPDATE comments
+SET content = $2, edited_at = now()
+WHERE id = $1
+RETURNING id, content, user_id, post_id, created_at, edited_at, search_vector
+`
+
+type UpdateCommentParams struct {
+	ID      uuid.UUID `json:"id"`
+	Content string    `json:"content"`
+}
+
+func (q *Queries) UpdateComment(ctx context.Context, arg UpdateCommentParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, updateComment, arg.ID, arg.Content)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.Content,
+		&i.UserID,
+		&i.PostID,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.SearchVector,
+	)
+	return i, err
+}
+
+const updateReply = `-- name: UpdateReply :one
+UPDATE replies
+SET content = $2, edited_at = now()
+WHERE id = $1
+RETURNING id, content, user_id, comment_id, created_at, edited_at
+`
+
+type UpdateReplyParams struct {
+	ID      uuid.UUID `json:"id"`
+	Content string    `json:"content"`
+}
+
+func (q *Queries) UpdateReply(ctx context.Context, arg UpdateReplyParams) (Reply, error) {
+	row := q.db.QueryRowContext(ctx, updateReply, arg.ID, arg.Content)
+	var i Reply
+	err := row.Scan(
+		&i.ID,
+		&i.Content,
+		&i.UserID,
+		&i.CommentID,
+		&i.CreatedAt,
+		&i.EditedAt,
 	)
 	return i, err
 }
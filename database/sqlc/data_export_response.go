@@ -0,0 +1,32 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DataExportResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Status      string     `json:"status"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (export DataExport) MakeResponse() DataExportResponse {
+	response := DataExportResponse{
+		ID:        export.ID,
+		Status:    export.Status,
+		CreatedAt: export.CreatedAt,
+	}
+
+	if export.ExpiresAt.Valid {
+		response.ExpiresAt = &export.ExpiresAt.Time
+	}
+	if export.CompletedAt.Valid {
+		response.CompletedAt = &export.CompletedAt.Time
+	}
+
+	return response
+}
@@ -7,8 +7,11 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const followUser = `-- name: FollowUser :one
@@ -30,34 +33,83 @@ func (q *Queries) FollowUser(ctx context.Context, arg FollowUserParams) (Follow,
 }
 
 const getFollowers = `-- name: GetFollowers :many
-SELECT following_users.id, following_users.username, following_users.password_hash, following_users.email, following_users.created_at FROM follows
+SELECT following_users.id, following_users.username, following_users.password_hash, following_users.email, following_users.created_at, following_users.is_verified, following_users.password_changed_at, following_users.role, following_users.deleted_at, following_users.purging_at, following_users.display_name, following_users.bio, following_users.country_code, following_users.avatar_key, following_users.main_event, following_users.wca_id, following_users.social_links, following_users.is_private, follows.created_at AS followed_at FROM follows
 INNER JOIN users as followed_users on follows.followed_user_id = followed_users.id
 INNER JOIN users as following_users on follows.user_id = following_users.id
 WHERE follows.followed_user_id = $1
+AND (
+  $4::timestamptz IS NULL
+  OR (follows.created_at, follows.user_id) < ($4, $5::uuid)
+)
+ORDER BY follows.created_at DESC, follows.user_id DESC
 LIMIT $2 OFFSET $3
 `
 
 type GetFollowersParams struct {
-	FollowedUserID uuid.UUID `json:"followed_user_id"`
-	Limit          int32     `json:"limit"`
-	Offset         int32     `json:"offset"`
+	FollowedUserID  uuid.UUID     `json:"followed_user_id"`
+	Limit           int32         `json:"limit"`
+	Offset          int32         `json:"offset"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+}
+
+type GetFollowersRow struct {
+	ID                uuid.UUID    `json:"id"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt         time.Time    `json:"created_at"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
+	FollowedAt        time.Time    `json:"followed_at"`
 }
 
-func (q *Queries) GetFollowers(ctx context.Context, arg GetFollowersParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, getFollowers, arg.FollowedUserID, arg.Limit, arg.Offset)
+func (q *Queries) GetFollowers(ctx context.Context, arg GetFollowersParams) ([]GetFollowersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFollowers,
+		arg.FollowedUserID,
+		arg.Limit,
+		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []User{}
+	items := []GetFollowersRow{}
 	for rows.Next() {
-		var i User
+		var i GetFollowersRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Username,
 			&i.PasswordHash,
 			&i.Email,
 			&i.CreatedAt,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
+			&i.FollowedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -84,34 +136,83 @@ func (q *Queries) GetFollowersCount(ctx context.Context, followedUserID uuid.UUI
 }
 
 const getFollowing = `-- name: GetFollowing :many
-SELECT followed_users.id, followed_users.username, followed_users.password_hash, followed_users.email, followed_users.created_at FROM follows
+SELECT followed_users.id, followed_users.username, followed_users.password_hash, followed_users.email, followed_users.created_at, followed_users.is_verified, followed_users.password_changed_at, followed_users.role, followed_users.deleted_at, followed_users.purging_at, followed_users.display_name, followed_users.bio, followed_users.country_code, followed_users.avatar_key, followed_users.main_event, followed_users.wca_id, followed_users.social_links, followed_users.is_private, follows.created_at AS followed_at FROM follows
 INNER JOIN users as followed_users on follows.followed_user_id = followed_users.id
 INNER JOIN users as following_users on follows.user_id = following_users.id
 WHERE follows.user_id = $1
+AND (
+  $4::timestamptz IS NULL
+  OR (follows.created_at, follows.followed_user_id) < ($4, $5::uuid)
+)
+ORDER BY follows.created_at DESC, follows.followed_user_id DESC
 LIMIT $2 OFFSET $3
 `
 
 type GetFollowingParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+	UserID          uuid.UUID     `json:"user_id"`
+	Limit           int32         `json:"limit"`
+	Offset          int32         `json:"offset"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+}
+
+type GetFollowingRow struct {
+	ID                uuid.UUID    `json:"id"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt         time.Time    `json:"created_at"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
+	FollowedAt        time.Time    `json:"followed_at"`
 }
 
-func (q *Queries) GetFollowing(ctx context.Context, arg GetFollowingParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, getFollowing, arg.UserID, arg.Limit, arg.Offset)
+func (q *Queries) GetFollowing(ctx context.Context, arg GetFollowingParams) ([]GetFollowingRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFollowing,
+		arg.UserID,
+		arg.Limit,
+		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []User{}
+	items := []GetFollowingRow{}
 	for rows.Next() {
-		var i User
+		var i GetFollowingRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Username,
 			&i.PasswordHash,
 			&i.Email,
 			&i.CreatedAt,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
+			&i.FollowedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -137,6 +238,24 @@ func (q *Queries) GetFollowingCount(ctx context.Context, userID uuid.UUID) (int6
 	return count, err
 }
 
+const isFollowing = `-- name: IsFollowing :one
+SELECT EXISTS(
+  SELECT 1 FROM follows WHERE user_id = $1 AND followed_user_id = $2
+)
+`
+
+type IsFollowingParams struct {
+	UserID         uuid.UUID `json:"user_id"`
+	FollowedUserID uuid.UUID `json:"followed_user_id"`
+}
+
+func (q *Queries) IsFollowing(ctx context.Context, arg IsFollowingParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isFollowing, arg.UserID, arg.FollowedUserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const unfollowUser = `-- name: UnfollowUser :exec
 DELETE FROM follows WHERE user_id = $1 AND followed_user_id = $2
 `
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Content    string     `json:"content"`
+	FromUserID uuid.UUID  `json:"from_user_id"`
+	ToUserID   uuid.UUID  `json:"to_user_id"`
+	ReadAt     *time.Time `json:"read_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (message Message) MakeResponse() MessageResponse {
+	var readAt *time.Time
+	if message.ReadAt.Valid {
+		readAt = &message.ReadAt.Time
+	}
+
+	return MessageResponse{
+		ID:         message.ID,
+		Content:    message.Content,
+		FromUserID: message.FromUserID,
+		ToUserID:   message.ToUserID,
+		ReadAt:     readAt,
+		CreatedAt:  message.CreatedAt,
+	}
+}
+
+type ConversationResponse struct {
+	User        UserResponse    `json:"user"`
+	LastMessage MessageResponse `json:"last_message"`
+	UnreadCount int64           `json:"unread_count"`
+}
+
+func (conversation GetConversationsRow) MakeResponse() ConversationResponse {
+	user := UserResponse{
+		ID:          conversation.ID,
+		Username:    conversation.Username,
+		CreatedAt:   conversation.CreatedAt,
+		DisplayName: conversation.DisplayName,
+		Bio:         conversation.Bio,
+		CountryCode: conversation.CountryCode,
+		AvatarKey:   conversation.AvatarKey,
+		MainEvent:   conversation.MainEvent,
+		WcaID:       conversation.WcaID,
+		SocialLinks: conversation.SocialLinks,
+		IsPrivate:   conversation.IsPrivate,
+	}
+
+	lastMessage := Message{
+		ID:         conversation.MessageID,
+		Content:    conversation.Content,
+		FromUserID: conversation.FromUserID,
+		ToUserID:   conversation.ToUserID,
+		ReadAt:     conversation.ReadAt,
+		CreatedAt:  conversation.MessageCreatedAt,
+	}
+
+	return ConversationResponse{
+		User:        user,
+		LastMessage: lastMessage.MakeResponse(),
+		UnreadCount: conversation.UnreadCount,
+	}
+}
@@ -5,46 +5,191 @@
 package database
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AuditLog struct {
+	ID        uuid.UUID     `json:"id"`
+	UserID    uuid.NullUUID `json:"user_id"`
+	Action    string        `json:"action"`
+	Username  string        `json:"username"`
+	ClientIp  string        `json:"client_ip"`
+	UserAgent string        `json:"user_agent"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Block struct {
+	UserID        uuid.UUID `json:"user_id"`
+	BlockedUserID uuid.UUID `json:"blocked_user_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
 type Comment struct {
+	ID           uuid.UUID    `json:"id"`
+	Content      string       `json:"content"`
+	UserID       uuid.UUID    `json:"user_id"`
+	PostID       uuid.UUID    `json:"post_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+	EditedAt     sql.NullTime `json:"edited_at"`
+	SearchVector string       `json:"-"`
+}
+
+type CommentReaction struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Reaction  string    `json:"reaction"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CommentRevision struct {
 	ID        uuid.UUID `json:"id"`
+	CommentID uuid.UUID `json:"comment_id"`
 	Content   string    `json:"content"`
-	UserID    uuid.UUID `json:"user_id"`
-	PostID    uuid.UUID `json:"post_id"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type DataExport struct {
+	ID          uuid.UUID      `json:"id"`
+	UserID      uuid.UUID      `json:"user_id"`
+	Status      string         `json:"status"`
+	ObjectKey   sql.NullString `json:"object_key"`
+	ExpiresAt   sql.NullTime   `json:"expires_at"`
+	CompletedAt sql.NullTime   `json:"completed_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type EmailVerificationToken struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
 type Follow struct {
 	UserID         uuid.UUID `json:"user_id"`
 	FollowedUserID uuid.UUID `json:"followed_user_id"`
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+type FollowRequest struct {
+	UserID          uuid.UUID `json:"user_id"`
+	RequestedUserID uuid.UUID `json:"requested_user_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type LoginAttempt struct {
+	Key           string       `json:"key"`
+	Failures      int32        `json:"failures"`
+	LastFailureAt time.Time    `json:"last_failure_at"`
+	LockedUntil   sql.NullTime `json:"locked_until"`
+}
+
 type Message struct {
-	ID         uuid.UUID `json:"id"`
-	Content    string    `json:"content"`
-	FromUserID uuid.UUID `json:"from_user_id"`
-	ToUserID   uuid.UUID `json:"to_user_id"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID         uuid.UUID    `json:"id"`
+	Content    string       `json:"content"`
+	FromUserID uuid.UUID    `json:"from_user_id"`
+	ToUserID   uuid.UUID    `json:"to_user_id"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ReadAt     sql.NullTime `json:"read_at"`
+}
+
+type MfaToken struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type Mute struct {
+	UserID      uuid.UUID `json:"user_id"`
+	MutedUserID uuid.UUID `json:"muted_user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PasswordResetToken struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type PersonalAccessToken struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	Name        string       `json:"name"`
+	TokenHash   string       `json:"token_hash"`
+	TokenPrefix string       `json:"token_prefix"`
+	Scopes      []string     `json:"scopes"`
+	ExpiresAt   sql.NullTime `json:"expires_at"`
+	LastUsedAt  sql.NullTime `json:"last_used_at"`
+	RevokedAt   sql.NullTime `json:"revoked_at"`
+	CreatedAt   time.Time    `json:"created_at"`
 }
 
 type Post struct {
+	ID           uuid.UUID    `json:"id"`
+	TextContent  string       `json:"text_content"`
+	ImageCount   int32        `json:"image_count"`
+	UserID       uuid.UUID    `json:"user_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+	EditedAt     sql.NullTime `json:"edited_at"`
+	ImageKeys    []string     `json:"image_keys"`
+	SearchVector string       `json:"-"`
+}
+
+type PostReaction struct {
+	PostID    uuid.UUID `json:"post_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Reaction  string    `json:"reaction"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PostRevision struct {
 	ID          uuid.UUID `json:"id"`
+	PostID      uuid.UUID `json:"post_id"`
 	TextContent string    `json:"text_content"`
 	ImageCount  int32     `json:"image_count"`
-	UserID      uuid.UUID `json:"user_id"`
+	ImageKeys   []string  `json:"image_keys"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+type RecoveryCode struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
 type Reply struct {
+	ID        uuid.UUID    `json:"id"`
+	Content   string       `json:"content"`
+	UserID    uuid.UUID    `json:"user_id"`
+	CommentID uuid.UUID    `json:"comment_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	EditedAt  sql.NullTime `json:"edited_at"`
+}
+
+type ReplyReaction struct {
+	ReplyID   uuid.UUID `json:"reply_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Reaction  string    `json:"reaction"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReplyRevision struct {
 	ID        uuid.UUID `json:"id"`
+	ReplyID   uuid.UUID `json:"reply_id"`
 	Content   string    `json:"content"`
-	UserID    uuid.UUID `json:"user_id"`
-	CommentID uuid.UUID `json:"comment_id"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -56,12 +201,47 @@ type Session struct {
 	IsBlocked    bool      `json:"is_blocked"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	CreatedAt    time.Time `json:"created_at"`
+	FamilyID     uuid.UUID `json:"family_id"`
+	IsUsed       bool      `json:"is_used"`
+}
+
+type Timeline struct {
+	UserID    uuid.UUID `json:"user_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	AuthorID  uuid.UUID `json:"author_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TimelinePullAuthor struct {
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TotpSecret struct {
+	UserID          uuid.UUID `json:"user_id"`
+	Secret          string    `json:"secret"`
+	IsEnabled       bool      `json:"is_enabled"`
+	LastUsedCounter int64     `json:"last_used_counter"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 type User struct {
-	ID           uuid.UUID `json:"id"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"password_hash"`
-	Email        string    `json:"email"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID                uuid.UUID    `json:"id"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt         time.Time    `json:"created_at"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
 }
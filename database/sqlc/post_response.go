@@ -7,73 +7,206 @@ import (
 )
 
 type PostResponse struct {
-	ID          uuid.UUID    `json:"id"`
-	TextContent string       `json:"text_content"`
-	ImageCount  int32        `json:"image_count"`
-	User        UserResponse `json:"user"`
-	CreatedAt   time.Time    `json:"created_at"`
+	ID             uuid.UUID        `json:"id"`
+	TextContent    string           `json:"text_content"`
+	ImageCount     int32            `json:"image_count"`
+	ImageKeys      []string         `json:"image_keys"`
+	User           UserResponse     `json:"user"`
+	CreatedAt      time.Time        `json:"created_at"`
+	EditedAt       *time.Time       `json:"edited_at"`
+	ReactionCounts map[string]int64 `json:"reaction_counts"`
+	ReactedByMe    bool             `json:"reacted_by_me"`
+	MyReaction     string           `json:"my_reaction"`
 }
 
 func (post GetPostByIdRow) MakeResponse() PostResponse {
 	user := UserResponse{
-		ID:        post.UserID,
-		Username:  post.Username,
-		CreatedAt: post.CreatedAt_2,
+		ID:          post.UserID,
+		Username:    post.Username,
+		CreatedAt:   post.CreatedAt_2,
+		DisplayName: post.DisplayName,
+		Bio:         post.Bio,
+		CountryCode: post.CountryCode,
+		AvatarKey:   post.AvatarKey,
+		MainEvent:   post.MainEvent,
+		WcaID:       post.WcaID,
+		SocialLinks: post.SocialLinks,
+		IsPrivate:   post.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if post.EditedAt.Valid {
+		editedAt = &post.EditedAt.Time
 	}
 
 	return PostResponse{
-		ID:          post.ID,
-		TextContent: post.TextContent,
-		ImageCount:  post.ImageCount,
-		CreatedAt:   post.CreatedAt,
-		User:        user,
+		ID:             post.ID,
+		TextContent:    post.TextContent,
+		ImageCount:     post.ImageCount,
+		ImageKeys:      post.ImageKeys,
+		CreatedAt:      post.CreatedAt,
+		EditedAt:       editedAt,
+		User:           user,
+		ReactionCounts: map[string]int64{},
 	}
 }
 
 func (post GetPostsByUserRow) MakeResponse() PostResponse {
 	user := UserResponse{
-		ID:        post.UserID,
-		Username:  post.Username,
-		CreatedAt: post.CreatedAt_2,
+		ID:          post.UserID,
+		Username:    post.Username,
+		CreatedAt:   post.CreatedAt_2,
+		DisplayName: post.DisplayName,
+		Bio:         post.Bio,
+		CountryCode: post.CountryCode,
+		AvatarKey:   post.AvatarKey,
+		MainEvent:   post.MainEvent,
+		WcaID:       post.WcaID,
+		SocialLinks: post.SocialLinks,
+		IsPrivate:   post.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if post.EditedAt.Valid {
+		editedAt = &post.EditedAt.Time
 	}
 
 	return PostResponse{
-		ID:          post.ID,
-		TextContent: post.TextContent,
-		ImageCount:  post.ImageCount,
-		CreatedAt:   post.CreatedAt,
-		User:        user,
+		ID:             post.ID,
+		TextContent:    post.TextContent,
+		ImageCount:     post.ImageCount,
+		ImageKeys:      post.ImageKeys,
+		CreatedAt:      post.CreatedAt,
+		EditedAt:       editedAt,
+		User:           user,
+		ReactionCounts: map[string]int64{},
 	}
 }
 
 func (post GetFeedRow) MakeResponse() PostResponse {
 	user := UserResponse{
-		ID:        post.UserID,
-		Username:  post.Username,
-		CreatedAt: post.CreatedAt_2,
+		ID:          post.UserID,
+		Username:    post.Username,
+		CreatedAt:   post.CreatedAt_2,
+		DisplayName: post.DisplayName,
+		Bio:         post.Bio,
+		CountryCode: post.CountryCode,
+		AvatarKey:   post.AvatarKey,
+		MainEvent:   post.MainEvent,
+		WcaID:       post.WcaID,
+		SocialLinks: post.SocialLinks,
+		IsPrivate:   post.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if post.EditedAt.Valid {
+		editedAt = &post.EditedAt.Time
+	}
+
+	return PostResponse{
+		ID:             post.ID,
+		TextContent:    post.TextContent,
+		ImageCount:     post.ImageCount,
+		ImageKeys:      post.ImageKeys,
+		CreatedAt:      post.CreatedAt,
+		EditedAt:       editedAt,
+		User:           user,
+		ReactionCounts: map[string]int64{},
+	}
+}
+
+func (post GetPulledFeedPostsRow) MakeResponse() PostResponse {
+	user := UserResponse{
+		ID:          post.UserID,
+		Username:    post.Username,
+		CreatedAt:   post.CreatedAt_2,
+		DisplayName: post.DisplayName,
+		Bio:         post.Bio,
+		CountryCode: post.CountryCode,
+		AvatarKey:   post.AvatarKey,
+		MainEvent:   post.MainEvent,
+		WcaID:       post.WcaID,
+		SocialLinks: post.SocialLinks,
+		IsPrivate:   post.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if post.EditedAt.Valid {
+		editedAt = &post.EditedAt.Time
 	}
 
 	return PostResponse{
-		ID:          post.ID,
-		TextContent: post.TextContent,
-		ImageCount:  post.ImageCount,
-		CreatedAt:   post.CreatedAt,
-		User:        user,
+		ID:             post.ID,
+		TextContent:    post.TextContent,
+		ImageCount:     post.ImageCount,
+		ImageKeys:      post.ImageKeys,
+		CreatedAt:      post.CreatedAt,
+		EditedAt:       editedAt,
+		User:           user,
+		ReactionCounts: map[string]int64{},
 	}
 }
 
 func (post GetGuestFeedRow) MakeResponse() PostResponse {
 	user := UserResponse{
-		ID:        post.UserID,
-		Username:  post.Username,
-		CreatedAt: post.CreatedAt_2,
+		ID:          post.UserID,
+		Username:    post.Username,
+		CreatedAt:   post.CreatedAt_2,
+		DisplayName: post.DisplayName,
+		Bio:         post.Bio,
+		CountryCode: post.CountryCode,
+		AvatarKey:   post.AvatarKey,
+		MainEvent:   post.MainEvent,
+		WcaID:       post.WcaID,
+		SocialLinks: post.SocialLinks,
+		IsPrivate:   post.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if post.EditedAt.Valid {
+		editedAt = &post.EditedAt.Time
+	}
+
+	return PostResponse{
+		ID:             post.ID,
+		TextContent:    post.TextContent,
+		ImageCount:     post.ImageCount,
+		ImageKeys:      post.ImageKeys,
+		CreatedAt:      post.CreatedAt,
+		EditedAt:       editedAt,
+		User:           user,
+		ReactionCounts: map[string]int64{},
+	}
+}
+
+func (post GetRankedFeedCandidatesRow) MakeResponse() PostResponse {
+	user := UserResponse{
+		ID:          post.UserID,
+		Username:    post.Username,
+		CreatedAt:   post.CreatedAt_2,
+		DisplayName: post.DisplayName,
+		Bio:         post.Bio,
+		CountryCode: post.CountryCode,
+		AvatarKey:   post.AvatarKey,
+		MainEvent:   post.MainEvent,
+		WcaID:       post.WcaID,
+		SocialLinks: post.SocialLinks,
+		IsPrivate:   post.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if post.EditedAt.Valid {
+		editedAt = &post.EditedAt.Time
 	}
 
 	return PostResponse{
-		ID:          post.ID,
-		TextContent: post.TextContent,
-		ImageCount:  post.ImageCount,
-		CreatedAt:   post.CreatedAt,
-		User:        user,
+		ID:             post.ID,
+		TextContent:    post.TextContent,
+		ImageCount:     post.ImageCount,
+		ImageKeys:      post.ImageKeys,
+		CreatedAt:      post.CreatedAt,
+		EditedAt:       editedAt,
+		User:           user,
+		ReactionCounts: map[string]int64{},
 	}
 }
@@ -7,21 +7,24 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createPost = `-- name: CreatePost :one
-INSERT INTO posts(id, text_content, image_count, user_id)
-VALUES ($1, $2, $3, $4)
-RETURNING id, text_content, image_count, user_id, created_at
+INSERT INTO posts(id, text_content, image_count, image_keys, user_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, text_content, image_count, user_id, created_at, edited_at, image_keys, search_vector
 `
 
 type CreatePostParams struct {
 	ID          uuid.UUID `json:"id"`
 	TextContent string    `json:"text_content"`
 	ImageCount  int32     `json:"image_count"`
+	ImageKeys   []string  `json:"image_keys"`
 	UserID      uuid.UUID `json:"user_id"`
 }
 
@@ -30,6 +33,7 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		arg.ID,
 		arg.TextContent,
 		arg.ImageCount,
+		pq.Array(arg.ImageKeys),
 		arg.UserID,
 	)
 	var i Post
@@ -39,6 +43,9 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		&i.ImageCount,
 		&i.UserID,
 		&i.CreatedAt,
+		&i.EditedAt,
+		pq.Array(&i.ImageKeys),
+		&i.SearchVector,
 	)
 	return i, err
 }
@@ -52,41 +59,105 @@ func (q *Queries) DeletePost(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const getAllPostsByUser = `-- name: GetAllPostsByUser :many
+SELECT id, text_content, image_count, user_id, created_at, edited_at, image_keys, search_vector FROM posts
+WHERE user_id = $1
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetAllPostsByUser(ctx context.Context, userID uuid.UUID) ([]Post, error) {
+	rows, err := q.db.QueryContext(ctx, getAllPostsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Post{}
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.TextContent,
+			&i.ImageCount,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.EditedAt,
+			pq.Array(&i.ImageKeys),
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFeed = `-- name: GetFeed :many
-SELECT posts.id, text_content, image_count, user_id, posts.created_at, users.id, username, password_hash, email, users.created_at
-FROM posts
-INNER JOIN users ON posts.user_id = users.id
-WHERE user_id IN (
-  SELECT followed_user.id
-  FROM follows
-  INNER JOIN users as followed_user ON followed_user.id = follows.followed_user_id
-  WHERE follows.user_id = $1
+SELECT posts.id, posts.text_content, posts.image_count, posts.user_id, posts.created_at, posts.edited_at, posts.image_keys, posts.search_vector, users.id, users.username, users.password_hash, users.email, users.created_at, users.is_verified, users.password_changed_at, users.role, users.deleted_at, users.purging_at, users.display_name, users.bio, users.country_code, users.avatar_key, users.main_event, users.wca_id, users.social_links, users.is_private
+FROM timelines
+INNER JOIN posts ON posts.id = timelines.post_id
+INNER JOIN users ON users.id = timelines.author_id
+WHERE timelines.user_id = $1
+AND users.deleted_at IS NULL
+AND timelines.author_id NOT IN (
+  SELECT muted_user_id FROM mutes WHERE mutes.user_id = $1
 )
-ORDER BY posts.created_at DESC
-LIMIT $2 OFFSET $3
+AND (
+  $3::timestamptz IS NULL
+  OR (timelines.created_at, timelines.post_id) < ($3, $4::uuid)
+)
+ORDER BY timelines.created_at DESC, timelines.post_id DESC
+LIMIT $2
 `
 
 type GetFeedParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+	UserID          uuid.UUID     `json:"user_id"`
+	Limit           int32         `json:"limit"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
 }
 
 type GetFeedRow struct {
-	ID           uuid.UUID `json:"id"`
-	TextContent  string    `json:"text_content"`
-	ImageCount   int32     `json:"image_count"`
-	UserID       uuid.UUID `json:"user_id"`
-	CreatedAt    time.Time `json:"created_at"`
-	ID_2         uuid.UUID `json:"id_2"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"password_hash"`
-	Email        string    `json:"email"`
-	CreatedAt_2  time.Time `json:"created_at_2"`
+	ID                uuid.UUID    `json:"id"`
+	TextContent       string       `json:"text_content"`
+	ImageCount        int32        `json:"image_count"`
+	UserID            uuid.UUID    `json:"user_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	ImageKeys         []string     `json:"image_keys"`
+	SearchVector      string       `json:"-"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
 }
 
 func (q *Queries) GetFeed(ctx context.Context, arg GetFeedParams) ([]GetFeedRow, error) {
-	rows, err := q.db.QueryContext(ctx, getFeed, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getFeed,
+		arg.UserID,
+		arg.Limit,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -100,11 +171,27 @@ func (q *Queries) GetFeed(ctx context.Context, arg GetFeedParams) ([]GetFeedRow,
 			&i.ImageCount,
 			&i.UserID,
 			&i.CreatedAt,
+			&i.EditedAt,
+			pq.Array(&i.ImageKeys),
+			&i.SearchVector,
 			&i.ID_2,
 			&i.Username,
 			&i.PasswordHash,
 			&i.Email,
 			&i.CreatedAt_2,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
 		); err != nil {
 			return nil, err
 		}
@@ -120,33 +207,62 @@ func (q *Queries) GetFeed(ctx context.Context, arg GetFeedParams) ([]GetFeedRow,
 }
 
 const getGuestFeed = `-- name: GetGuestFeed :many
-SELECT posts.id, text_content, image_count, user_id, posts.created_at, users.id, username, password_hash, email, users.created_at
+SELECT posts.id, text_content, image_count, user_id, posts.created_at, edited_at, image_keys, search_vector, users.id, username, password_hash, email, users.created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 FROM posts
 INNER JOIN users ON posts.user_id = users.id
-ORDER BY posts.created_at DESC
+WHERE users.is_private = false
+AND users.deleted_at IS NULL
+AND (
+  $3::timestamptz IS NULL
+  OR (posts.created_at, posts.id) < ($3, $4::uuid)
+)
+ORDER BY posts.created_at DESC, posts.id DESC
 LIMIT $1 OFFSET $2
 `
 
 type GetGuestFeedParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Limit           int32         `json:"limit"`
+	Offset          int32         `json:"offset"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
 }
 
 type GetGuestFeedRow struct {
-	ID           uuid.UUID `json:"id"`
-	TextContent  string    `json:"text_content"`
-	ImageCount   int32     `json:"image_count"`
-	UserID       uuid.UUID `json:"user_id"`
-	CreatedAt    time.Time `json:"created_at"`
-	ID_2         uuid.UUID `json:"id_2"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"password_hash"`
-	Email        string    `json:"email"`
-	CreatedAt_2  time.Time `json:"created_at_2"`
+	ID                uuid.UUID    `json:"id"`
+	TextContent       string       `json:"text_content"`
+	ImageCount        int32        `json:"image_count"`
+	UserID            uuid.UUID    `json:"user_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	ImageKeys         []string     `json:"image_keys"`
+	SearchVector      string       `json:"-"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
 }
 
 func (q *Queries) GetGuestFeed(ctx context.Context, arg GetGuestFeedParams) ([]GetGuestFeedRow, error) {
-	rows, err := q.db.QueryContext(ctx, getGuestFeed, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getGuestFeed,
+		arg.Limit,
+		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -160,11 +276,27 @@ func (q *Queries) GetGuestFeed(ctx context.Context, arg GetGuestFeedParams) ([]G
 			&i.ImageCount,
 			&i.UserID,
 			&i.CreatedAt,
+			&i.EditedAt,
+			pq.Array(&i.ImageKeys),
+			&i.SearchVector,
 			&i.ID_2,
 			&i.Username,
 			&i.PasswordHash,
 			&i.Email,
 			&i.CreatedAt_2,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
 		); err != nil {
 			return nil, err
 		}
@@ -180,7 +312,7 @@ func (q *Queries) GetGuestFeed(ctx context.Context, arg GetGuestFeedParams) ([]G
 }
 
 const getPostById = `-- name: GetPostById :one
-SELECT posts.id, text_content, image_count, user_id, posts.created_at, users.id, username, password_hash, email, users.created_at
+SELECT posts.id, text_content, image_count, user_id, posts.created_at, edited_at, image_keys, search_vector, users.id, username, password_hash, email, users.created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 FROM posts
 INNER JOIN users ON posts.user_id = users.id
 WHERE posts.id = $1
@@ -188,16 +320,32 @@ LIMIT 1
 `
 
 type GetPostByIdRow struct {
-	ID           uuid.UUID `json:"id"`
-	TextContent  string    `json:"text_content"`
-	ImageCount   int32     `json:"image_count"`
-	UserID       uuid.UUID `json:"user_id"`
-	CreatedAt    time.Time `json:"created_at"`
-	ID_2         uuid.UUID `json:"id_2"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"password_hash"`
-	Email        string    `json:"email"`
-	CreatedAt_2  time.Time `json:"created_at_2"`
+	ID                uuid.UUID    `json:"id"`
+	TextContent       string       `json:"text_content"`
+	ImageCount        int32        `json:"image_count"`
+	UserID            uuid.UUID    `json:"user_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	ImageKeys         []string     `json:"image_keys"`
+	SearchVector      string       `json:"-"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
 }
 
 func (q *Queries) GetPostById(ctx context.Context, id uuid.UUID) (GetPostByIdRow, error) {
@@ -209,45 +357,152 @@ func (q *Queries) GetPostById(ctx context.Context, id uuid.UUID) (GetPostByIdRow
 		&i.ImageCount,
 		&i.UserID,
 		&i.CreatedAt,
+		&i.EditedAt,
+		pq.Array(&i.ImageKeys),
+		&i.SearchVector,
 		&i.ID_2,
 		&i.Username,
 		&i.PasswordHash,
 		&i.Email,
 		&i.CreatedAt_2,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
 	)
 	return i, err
 }
 
+const getPostImageKeys = `-- name: GetPostImageKeys :many
+SELECT unnest(image_keys)::varchar AS image_key FROM posts
+WHERE posts.id = $1
+UNION
+SELECT unnest(post_revisions.image_keys)::varchar FROM post_revisions
+WHERE post_revisions.post_id = $1
+`
+
+func (q *Queries) GetPostImageKeys(ctx context.Context, id uuid.UUID) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getPostImageKeys, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var image_key string
+		if err := rows.Scan(&image_key); err != nil {
+			return nil, err
+		}
+		items = append(items, image_key)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPostImageKeysByUser = `-- name: GetPostImageKeysByUser :many
+SELECT unnest(image_keys)::varchar AS image_key FROM posts
+WHERE posts.user_id = $1
+UNION
+SELECT unnest(post_revisions.image_keys)::varchar FROM post_revisions
+INNER JOIN posts ON post_revisions.post_id = posts.id
+WHERE posts.user_id = $1
+`
+
+func (q *Queries) GetPostImageKeysByUser(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getPostImageKeysByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var image_key string
+		if err := rows.Scan(&image_key); err != nil {
+			return nil, err
+		}
+		items = append(items, image_key)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPostsByUser = `-- name: GetPostsByUser :many
-SELECT posts.id, text_content, image_count, user_id, posts.created_at, users.id, username, password_hash, email, users.created_at
+SELECT posts.id, text_content, image_count, user_id, posts.created_at, edited_at, image_keys, search_vector, users.id, username, password_hash, email, users.created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 FROM posts
 INNER JOIN users ON posts.user_id = users.id
 WHERE user_id = $1
-ORDER BY posts.created_at DESC
+AND (
+  $4::timestamptz IS NULL
+  OR (posts.created_at, posts.id) < ($4, $5::uuid)
+)
+ORDER BY posts.created_at DESC, posts.id DESC
 LIMIT $2 OFFSET $3
 `
 
 type GetPostsByUserParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+	UserID          uuid.UUID     `json:"user_id"`
+	Limit           int32         `json:"limit"`
+	Offset          int32         `json:"offset"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
 }
 
 type GetPostsByUserRow struct {
-	ID           uuid.UUID `json:"id"`
-	TextContent  string    `json:"text_content"`
-	ImageCount   int32     `json:"image_count"`
-	UserID       uuid.UUID `json:"user_id"`
-	CreatedAt    time.Time `json:"created_at"`
-	ID_2         uuid.UUID `json:"id_2"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"password_hash"`
-	Email        string    `json:"email"`
-	CreatedAt_2  time.Time `json:"created_at_2"`
+	ID                uuid.UUID    `json:"id"`
+	TextContent       string       `json:"text_content"`
+	ImageCount        int32        `json:"image_count"`
+	UserID            uuid.UUID    `json:"user_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	ImageKeys         []string     `json:"image_keys"`
+	SearchVector      string       `json:"-"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
 }
 
 func (q *Queries) GetPostsByUser(ctx context.Context, arg GetPostsByUserParams) ([]GetPostsByUserRow, error) {
-	rows, err := q.db.QueryContext(ctx, getPostsByUser, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getPostsByUser,
+		arg.UserID,
+		arg.Limit,
+		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -261,11 +516,27 @@ func (q *Queries) GetPostsByUser(ctx context.Context, arg GetPostsByUserParams)
 			&i.ImageCount,
 			&i.UserID,
 			&i.CreatedAt,
+			&i.EditedAt,
+			pq.Array(&i.ImageKeys),
+			&i.SearchVector,
 			&i.ID_2,
 			&i.Username,
 			&i.PasswordHash,
 			&i.Email,
 			&i.CreatedAt_2,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
 		); err != nil {
 			return nil, err
 		}
@@ -279,3 +550,296 @@ func (q *Queries) GetPostsByUser(ctx context.Context, arg GetPostsByUserParams)
 	}
 	return items, nil
 }
+
+const getPulledFeedPosts = `-- name: GetPulledFeedPosts :many
+SELECT pulled_posts.id, pulled_posts.text_content, pulled_posts.image_count, pulled_posts.user_id, pulled_posts.created_at, pulled_posts.edited_at, pulled_posts.image_keys, pulled_posts.search_vector, users.id, users.username, users.password_hash, users.email, users.created_at, users.is_verified, users.password_changed_at, users.role, users.deleted_at, users.purging_at, users.display_name, users.bio, users.country_code, users.avatar_key, users.main_event, users.wca_id, users.social_links, users.is_private
+FROM follows
+INNER JOIN timeline_pull_authors ON timeline_pull_authors.user_id = follows.followed_user_id
+INNER JOIN users ON users.id = follows.followed_user_id
+CROSS JOIN LATERAL (
+  SELECT id, text_content, image_count, user_id, created_at, edited_at, image_keys, search_vector FROM posts
+  WHERE posts.user_id = follows.followed_user_id
+  AND (
+    $1::timestamptz IS NULL
+    OR (posts.created_at, posts.id) < ($1, $2::uuid)
+  )
+  ORDER BY posts.created_at DESC, posts.id DESC
+  LIMIT $3
+) AS pulled_posts
+WHERE follows.user_id = $4
+AND users.deleted_at IS NULL
+AND follows.followed_user_id NOT IN (
+  SELECT muted_user_id FROM mutes WHERE mutes.user_id = $4
+)
+ORDER BY pulled_posts.created_at DESC, pulled_posts.id DESC
+LIMIT $3
+`
+
+type GetPulledFeedPostsParams struct {
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	Limit           int32         `json:"limit"`
+	UserID          uuid.UUID     `json:"user_id"`
+}
+
+type GetPulledFeedPostsRow struct {
+	ID                uuid.UUID    `json:"id"`
+	TextContent       string       `json:"text_content"`
+	ImageCount        int32        `json:"image_count"`
+	UserID            uuid.UUID    `json:"user_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	ImageKeys         []string     `json:"image_keys"`
+	SearchVector      string       `json:"-"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
+}
+
+func (q *Queries) GetPulledFeedPosts(ctx context.Context, arg GetPulledFeedPostsParams) ([]GetPulledFeedPostsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPulledFeedPosts,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+		arg.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPulledFeedPostsRow{}
+	for rows.Next() {
+		var i GetPulledFeedPostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TextContent,
+			&i.ImageCount,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.EditedAt,
+			pq.Array(&i.ImageKeys),
+			&i.SearchVector,
+			&i.ID_2,
+			&i.Username,
+			&i.PasswordHash,
+			&i.Email,
+			&i.CreatedAt_2,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRankedFeedCandidates = `-- name: GetRankedFeedCandidates :many
+SELECT posts.id, posts.text_content, posts.image_count, posts.user_id, posts.created_at, posts.edited_at, posts.image_keys, posts.search_vector, users.id, users.username, users.password_hash, users.email, users.created_at, users.is_verified, users.password_changed_at, users.role, users.deleted_at, users.purging_at, users.display_name, users.bio, users.country_code, users.avatar_key, users.main_event, users.wca_id, users.social_links, users.is_private,
+  (
+    SELECT COUNT(*) FROM post_reactions
+    WHERE post_reactions.post_id = posts.id
+  ) AS reaction_count,
+  (
+    SELECT COUNT(*) FROM comments
+    WHERE comments.post_id = posts.id
+  ) AS comment_count,
+  ((
+    SELECT COUNT(*) FROM post_reactions
+    INNER JOIN posts AS reacted_posts ON post_reactions.post_id = reacted_posts.id
+    WHERE post_reactions.user_id = $1 AND reacted_posts.user_id = posts.user_id
+  ) + (
+    SELECT COUNT(*) FROM comments
+    INNER JOIN posts AS commented_posts ON comments.post_id = commented_posts.id
+    WHERE comments.user_id = $1 AND commented_posts.user_id = posts.user_id
+  ))::bigint AS affinity,
+  EXISTS(
+    SELECT 1 FROM follows
+    WHERE follows.user_id = $1 AND follows.followed_user_id = posts.user_id
+  ) AS is_followed
+FROM posts
+INNER JOIN users ON posts.user_id = users.id
+WHERE posts.created_at > $2 AND posts.created_at <= $3
+AND posts.user_id <> $1
+AND users.deleted_at IS NULL
+AND (
+  users.is_private = false
+  OR posts.user_id IN (SELECT followed_user_id FROM follows WHERE follows.user_id = $1)
+)
+AND posts.user_id NOT IN (
+  SELECT muted_user_id FROM mutes WHERE mutes.user_id = $1
+)
+AND NOT EXISTS (
+  SELECT 1 FROM blocks
+  WHERE (blocks.user_id = $1 AND blocks.blocked_user_id = posts.user_id)
+  OR (blocks.user_id = posts.user_id AND blocks.blocked_user_id = $1)
+)
+ORDER BY posts.created_at DESC
+LIMIT $4
+`
+
+type GetRankedFeedCandidatesParams struct {
+	ViewerID       uuid.UUID `json:"viewer_id"`
+	Since          time.Time `json:"since"`
+	Until          time.Time `json:"until"`
+	CandidateLimit int32     `json:"candidate_limit"`
+}
+
+type GetRankedFeedCandidatesRow struct {
+	ID                uuid.UUID    `json:"id"`
+	TextContent       string       `json:"text_content"`
+	ImageCount        int32        `json:"image_count"`
+	UserID            uuid.UUID    `json:"user_id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	EditedAt          sql.NullTime `json:"edited_at"`
+	ImageKeys         []string     `json:"image_keys"`
+	SearchVector      string       `json:"-"`
+	ID_2              uuid.UUID    `json:"id_2"`
+	Username          string       `json:"username"`
+	PasswordHash      string       `json:"password_hash"`
+	Email             string       `json:"email"`
+	CreatedAt_2       time.Time    `json:"created_at_2"`
+	IsVerified        bool         `json:"is_verified"`
+	PasswordChangedAt time.Time    `json:"password_changed_at"`
+	Role              string       `json:"role"`
+	DeletedAt         sql.NullTime `json:"deleted_at"`
+	PurgingAt         sql.NullTime `json:"purging_at"`
+	DisplayName       string       `json:"display_name"`
+	Bio               string       `json:"bio"`
+	CountryCode       string       `json:"country_code"`
+	AvatarKey         string       `json:"avatar_key"`
+	MainEvent         string       `json:"main_event"`
+	WcaID             string       `json:"wca_id"`
+	SocialLinks       []string     `json:"social_links"`
+	IsPrivate         bool         `json:"is_private"`
+	ReactionCount     int64        `json:"reaction_count"`
+	CommentCount      int64        `json:"comment_count"`
+	Affinity          int64        `json:"affinity"`
+	IsFollowed        bool         `json:"is_followed"`
+}
+
+func (q *Queries) GetRankedFeedCandidates(ctx context.Context, arg GetRankedFeedCandidatesParams) ([]GetRankedFeedCandidatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRankedFeedCandidates,
+		arg.ViewerID,
+		arg.Since,
+		arg.Until,
+		arg.CandidateLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRankedFeedCandidatesRow{}
+	for rows.Next() {
+		var i GetRankedFeedCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TextContent,
+			&i.ImageCount,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.EditedAt,
+			pq.Array(&i.ImageKeys),
+			&i.SearchVector,
+			&i.ID_2,
+			&i.Username,
+			&i.PasswordHash,
+			&i.Email,
+			&i.CreatedAt_2,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
+			&i.ReactionCount,
+			&i.CommentCount,
+			&i.Affinity,
+			&i.IsFollowed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updatePost = `-- name: UpdatePost :one
+UPDATE posts
+SET text_content = $2, image_count = $3, image_keys = $4, edited_at = now()
+WHERE id = $1
+RETURNING id, text_content, image_count, user_id, created_at, edited_at, image_keys, search_vector
+`
+
+type UpdatePostParams struct {
+	ID          uuid.UUID `json:"id"`
+	TextContent string    `json:"text_content"`
+	ImageCount  int32     `json:"image_count"`
+	ImageKeys   []string  `json:"image_keys"`
+}
+
+func (q *Queries) UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, error) {
+	row := q.db.QueryRowContext(ctx, updatePost,
+		arg.ID,
+		arg.TextContent,
+		arg.ImageCount,
+		pq.Array(arg.ImageKeys),
+	)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.TextContent,
+		&i.ImageCount,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.EditedAt,
+		pq.Array(&i.ImageKeys),
+		&i.SearchVector,
+	)
+	return i, err
+}
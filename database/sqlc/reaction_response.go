@@ -0,0 +1,70 @@
+package database
+
+// ReactionUserResponse is one entry of the "who reacted" lists
+type ReactionUserResponse struct {
+	Reaction string       `json:"reaction"`
+	User     UserResponse `json:"user"`
+}
+
+func (row GetPostReactionUsersRow) MakeResponse() ReactionUserResponse {
+	user := UserResponse{
+		ID:          row.ID,
+		Username:    row.Username,
+		CreatedAt:   row.CreatedAt,
+		DisplayName: row.DisplayName,
+		Bio:         row.Bio,
+		CountryCode: row.CountryCode,
+		AvatarKey:   row.AvatarKey,
+		MainEvent:   row.MainEvent,
+		WcaID:       row.WcaID,
+		SocialLinks: row.SocialLinks,
+		IsPrivate:   row.IsPrivate,
+	}
+
+	return ReactionUserResponse{
+		Reaction: row.Reaction,
+		User:     user,
+	}
+}
+
+func (row GetCommentReactionUsersRow) MakeResponse() ReactionUserResponse {
+	user := UserResponse{
+		ID:          row.ID,
+		Username:    row.Username,
+		CreatedAt:   row.CreatedAt,
+		DisplayName: row.DisplayName,
+		Bio:         row.Bio,
+		CountryCode: row.CountryCode,
+		AvatarKey:   row.AvatarKey,
+		MainEvent:   row.MainEvent,
+		WcaID:       row.WcaID,
+		SocialLinks: row.SocialLinks,
+		IsPrivate:   row.IsPrivate,
+	}
+
+	return ReactionUserResponse{
+		Reaction: row.Reaction,
+		User:     user,
+	}
+}
+
+func (row GetReplyReactionUsersRow) MakeResponse() ReactionUserResponse {
+	user := UserResponse{
+		ID:          row.ID,
+		Username:    row.Username,
+		CreatedAt:   row.CreatedAt,
+		DisplayName: row.DisplayName,
+		Bio:         row.Bio,
+		CountryCode: row.CountryCode,
+		AvatarKey:   row.AvatarKey,
+		MainEvent:   row.MainEvent,
+		WcaID:       row.WcaID,
+		SocialLinks: row.SocialLinks,
+		IsPrivate:   row.IsPrivate,
+	}
+
+	return ReactionUserResponse{
+		Reaction: row.Reaction,
+		User:     user,
+	}
+}
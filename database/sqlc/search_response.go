@@ -0,0 +1,84 @@
+package database
+
+import (
+	"time"
+)
+
+func (post SearchPostsRow) MakeResponse() PostResponse {
+	user := UserResponse{
+		ID:          post.UserID,
+		Username:    post.Username,
+		CreatedAt:   post.CreatedAt_2,
+		DisplayName: post.DisplayName,
+		Bio:         post.Bio,
+		CountryCode: post.CountryCode,
+		AvatarKey:   post.AvatarKey,
+		MainEvent:   post.MainEvent,
+		WcaID:       post.WcaID,
+		SocialLinks: post.SocialLinks,
+		IsPrivate:   post.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if post.EditedAt.Valid {
+		editedAt = &post.EditedAt.Time
+	}
+
+	return PostResponse{
+		ID:             post.ID,
+		TextContent:    post.TextContent,
+		ImageCount:     post.ImageCount,
+		ImageKeys:      post.ImageKeys,
+		CreatedAt:      post.CreatedAt,
+		EditedAt:       editedAt,
+		User:           user,
+		ReactionCounts: map[string]int64{},
+	}
+}
+
+func (comment SearchCommentsRow) MakeResponse() CommentResponse {
+	user := UserResponse{
+		ID:          comment.UserID,
+		Username:    comment.Username,
+		CreatedAt:   comment.CreatedAt_2,
+		DisplayName: comment.DisplayName,
+		Bio:         comment.Bio,
+		CountryCode: comment.CountryCode,
+		AvatarKey:   comment.AvatarKey,
+		MainEvent:   comment.MainEvent,
+		WcaID:       comment.WcaID,
+		SocialLinks: comment.SocialLinks,
+		IsPrivate:   comment.IsPrivate,
+	}
+
+	var editedAt *time.Time
+	if comment.EditedAt.Valid {
+		editedAt = &comment.EditedAt.Time
+	}
+
+	return CommentResponse{
+		ID:              comment.ID,
+		Content:         comment.Content,
+		NumberOfReplies: comment.NumberOfReplies,
+		CreatedAt:       comment.CreatedAt,
+		EditedAt:        editedAt,
+		User:            user,
+		ReactionCounts:  map[string]int64{},
+	}
+}
+
+func (user SearchUsersRow) MakeResponse() UserResponse {
+	return UserResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		CreatedAt:   user.CreatedAt,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		CountryCode: user.CountryCode,
+		AvatarKey:   user.AvatarKey,
+		MainEvent:   user.MainEvent,
+		WcaID:       user.WcaID,
+		SocialLinks: user.SocialLinks,
+		IsPrivate:   user.IsPrivate,
+	}
+}
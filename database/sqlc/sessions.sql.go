@@ -12,10 +12,67 @@ import (
 	"github.com/google/uuid"
 )
 
+const blockOtherUserSessions = `-- name: BlockOtherUserSessions :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE user_id = $1 AND id <> $2
+`
+
+type BlockOtherUserSessionsParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	ID     uuid.UUID `json:"id"`
+}
+
+func (q *Queries) BlockOtherUserSessions(ctx context.Context, arg BlockOtherUserSessionsParams) error {
+	_, err := q.db.ExecContext(ctx, blockOtherUserSessions, arg.UserID, arg.ID)
+	return err
+}
+
+const blockSession = `-- name: BlockSession :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1 AND user_id = $2
+`
+
+type BlockSessionParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) BlockSession(ctx context.Context, arg BlockSessionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, blockSession, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const blockSessionFamily = `-- name: BlockSessionFamily :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1
+`
+
+func (q *Queries) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, blockSessionFamily, familyID)
+	return err
+}
+
+const blockUserSessions = `-- name: BlockUserSessions :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE user_id = $1
+`
+
+func (q *Queries) BlockUserSessions(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, blockUserSessions, userID)
+	return err
+}
+
 const createSession = `-- name: CreateSession :one
-INSERT INTO sessions(id, user_id, refresh_token, client_ip, is_blocked, expires_at)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, refresh_token, client_ip, is_blocked, expires_at, created_at
+INSERT INTO sessions(id, user_id, refresh_token, client_ip, is_blocked, expires_at, family_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, refresh_token, client_ip, is_blocked, expires_at, created_at, family_id, is_used
 `
 
 type CreateSessionParams struct {
@@ -25,6 +82,7 @@ type CreateSessionParams struct {
 	ClientIp     string    `json:"client_ip"`
 	IsBlocked    bool      `json:"is_blocked"`
 	ExpiresAt    time.Time `json:"expires_at"`
+	FamilyID     uuid.UUID `json:"family_id"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
@@ -35,6 +93,7 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		arg.ClientIp,
 		arg.IsBlocked,
 		arg.ExpiresAt,
+		arg.FamilyID,
 	)
 	var i Session
 	err := row.Scan(
@@ -45,12 +104,92 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.IsBlocked,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.FamilyID,
+		&i.IsUsed,
 	)
 	return i, err
 }
 
+const getActiveSessionsByUser = `-- name: GetActiveSessionsByUser :many
+SELECT id, user_id, refresh_token, client_ip, is_blocked, expires_at, created_at, family_id, is_used FROM sessions
+WHERE user_id = $1 AND is_blocked = false AND is_used = false AND expires_at > now()
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetActiveSessionsByUser(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveSessionsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.RefreshToken,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.FamilyID,
+			&i.IsUsed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllSessionsByUser = `-- name: GetAllSessionsByUser :many
+SELECT id, user_id, refresh_token, client_ip, is_blocked, expires_at, created_at, family_id, is_used FROM sessions
+WHERE user_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetAllSessionsByUser(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, getAllSessionsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.RefreshToken,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.FamilyID,
+			&i.IsUsed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getSessionById = `-- name: GetSessionById :one
-SELECT id, user_id, refresh_token, client_ip, is_blocked, expires_at, created_at FROM sessions WHERE id = $1 LIMIT 1
+SELECT id, user_id, refresh_token, client_ip, is_blocked, expires_at, created_at, family_id, is_used FROM sessions WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetSessionById(ctx context.Context, id uuid.UUID) (Session, error) {
@@ -64,6 +203,22 @@ func (q *Queries) GetSessionById(ctx context.Context, id uuid.UUID) (Session, er
 		&i.IsBlocked,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.FamilyID,
+		&i.IsUsed,
 	)
 	return i, err
 }
+
+const markSessionAsUsed = `-- name: MarkSessionAsUsed :execrows
+UPDATE sessions
+SET is_used = true
+WHERE id = $1 AND is_used = false
+`
+
+func (q *Queries) MarkSessionAsUsed(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markSessionAsUsed, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Store provides all the generated queries plus the ability to run several of
+// them inside a single transaction
+type Store struct {
+	*Queries
+	db *sql.DB
+}
+
+func NewStore(db *sql.DB) *Store {
+	return &Store{
+		Queries: New(db),
+		db:      db,
+	}
+}
+
+func (store *Store) ExecTx(context context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(context, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(store.WithTx(tx))
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction error: %v, rollback error: %v", err, rollbackErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
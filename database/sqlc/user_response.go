@@ -7,15 +7,127 @@ import (
 )
 
 type UserResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Username  string    `json:"username"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	CreatedAt   time.Time `json:"created_at"`
+	DisplayName string    `json:"display_name"`
+	Bio         string    `json:"bio"`
+	CountryCode string    `json:"country_code"`
+	AvatarKey   string    `json:"avatar_key"`
+	MainEvent   string    `json:"main_event"`
+	WcaID       string    `json:"wca_id"`
+	SocialLinks []string  `json:"social_links"`
+	IsPrivate   bool      `json:"is_private"`
 }
 
 func (user User) MakeResponse() UserResponse {
 	return UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		CreatedAt: user.CreatedAt,
+		ID:          user.ID,
+		Username:    user.Username,
+		CreatedAt:   user.CreatedAt,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		CountryCode: user.CountryCode,
+		AvatarKey:   user.AvatarKey,
+		MainEvent:   user.MainEvent,
+		WcaID:       user.WcaID,
+		SocialLinks: user.SocialLinks,
+		IsPrivate:   user.IsPrivate,
+	}
+}
+
+func (user GetFollowersRow) MakeResponse() UserResponse {
+	return UserResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		CreatedAt:   user.CreatedAt,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		CountryCode: user.CountryCode,
+		AvatarKey:   user.AvatarKey,
+		MainEvent:   user.MainEvent,
+		WcaID:       user.WcaID,
+		SocialLinks: user.SocialLinks,
+		IsPrivate:   user.IsPrivate,
+	}
+}
+
+func (user GetFollowingRow) MakeResponse() UserResponse {
+	return UserResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		CreatedAt:   user.CreatedAt,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		CountryCode: user.CountryCode,
+		AvatarKey:   user.AvatarKey,
+		MainEvent:   user.MainEvent,
+		WcaID:       user.WcaID,
+		SocialLinks: user.SocialLinks,
+		IsPrivate:   user.IsPrivate,
+	}
+}
+
+func (user GetReceivedFollowRequestsRow) MakeResponse() UserResponse {
+	return UserResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		CreatedAt:   user.CreatedAt,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		CountryCode: user.CountryCode,
+		AvatarKey:   user.AvatarKey,
+		MainEvent:   user.MainEvent,
+		WcaID:       user.WcaID,
+		SocialLinks: user.SocialLinks,
+		IsPrivate:   user.IsPrivate,
+	}
+}
+
+func (user GetSentFollowRequestsRow) MakeResponse() UserResponse {
+	return UserResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		CreatedAt:   user.CreatedAt,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		CountryCode: user.CountryCode,
+		AvatarKey:   user.AvatarKey,
+		MainEvent:   user.MainEvent,
+		WcaID:       user.WcaID,
+		SocialLinks: user.SocialLinks,
+		IsPrivate:   user.IsPrivate,
+	}
+}
+
+func (user GetBlockedUsersRow) MakeResponse() UserResponse {
+	return UserResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		CreatedAt:   user.CreatedAt,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		CountryCode: user.CountryCode,
+		AvatarKey:   user.AvatarKey,
+		MainEvent:   user.MainEvent,
+		WcaID:       user.WcaID,
+		SocialLinks: user.SocialLinks,
+		IsPrivate:   user.IsPrivate,
+	}
+}
+
+func (user GetMutedUsersRow) MakeResponse() UserResponse {
+	return UserResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		CreatedAt:   user.CreatedAt,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		CountryCode: user.CountryCode,
+		AvatarKey:   user.AvatarKey,
+		MainEvent:   user.MainEvent,
+		WcaID:       user.WcaID,
+		SocialLinks: user.SocialLinks,
+		IsPrivate:   user.IsPrivate,
 	}
 }
@@ -7,14 +7,35 @@ package database
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const claimUserPurge = `-- name: ClaimUserPurge :execrows
+UPDATE users
+SET purging_at = now()
+WHERE id = $1 AND deleted_at < $2
+`
+
+type ClaimUserPurgeParams struct {
+	ID        uuid.UUID    `json:"id"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) ClaimUserPurge(ctx context.Context, arg ClaimUserPurgeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, claimUserPurge, arg.ID, arg.DeletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users(id, username, password_hash, email)
 VALUES ($1, $2, $3, $4)
-RETURNING id, username, password_hash, email, created_at
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 `
 
 type CreateUserParams struct {
@@ -38,12 +59,55 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.PasswordHash,
 		&i.Email,
 		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private FROM users WHERE email = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
 	)
 	return i, err
 }
 
 const getUserById = `-- name: GetUserById :one
-SELECT id, username, password_hash, email, created_at FROM users WHERE id = $1 LIMIT 1
+SELECT id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private FROM users WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetUserById(ctx context.Context, id uuid.UUID) (User, error) {
@@ -55,12 +119,25 @@ func (q *Queries) GetUserById(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.PasswordHash,
 		&i.Email,
 		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, password_hash, email, created_at FROM users WHERE username = $1 LIMIT 1
+SELECT id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private FROM users WHERE username = $1 LIMIT 1
 `
 
 func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
@@ -72,25 +149,52 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.PasswordHash,
 		&i.Email,
 		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
 	)
 	return i, err
 }
 
 const getUsersByUsername = `-- name: GetUsersByUsername :many
-SELECT id, username, password_hash, email, created_at FROM users
-WHERE username LIKE $3
+SELECT id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private FROM users
+WHERE username LIKE $3 AND deleted_at IS NULL
+AND NOT EXISTS (
+  SELECT 1 FROM blocks
+  WHERE (blocks.user_id = $4 AND blocks.blocked_user_id = users.id)
+  OR (blocks.user_id = users.id AND blocks.blocked_user_id = $4)
+)
+AND ($5::varchar IS NULL OR username > $5)
 ORDER BY username ASC
 LIMIT $1 OFFSET $2
 `
 
 type GetUsersByUsernameParams struct {
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
-	Input  string `json:"input"`
+	Limit          int32          `json:"limit"`
+	Offset         int32          `json:"offset"`
+	Input          string         `json:"input"`
+	ViewerID       uuid.UUID      `json:"viewer_id"`
+	CursorUsername sql.NullString `json:"cursor_username"`
 }
 
 func (q *Queries) GetUsersByUsername(ctx context.Context, arg GetUsersByUsernameParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, getUsersByUsername, arg.Limit, arg.Offset, arg.Input)
+	rows, err := q.db.QueryContext(ctx, getUsersByUsername,
+		arg.Limit,
+		arg.Offset,
+		arg.Input,
+		arg.ViewerID,
+		arg.CursorUsername,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +208,19 @@ func (q *Queries) GetUsersByUsername(ctx context.Context, arg GetUsersByUsername
 			&i.PasswordHash,
 			&i.Email,
 			&i.CreatedAt,
+			&i.IsVerified,
+			&i.PasswordChangedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.PurgingAt,
+			&i.DisplayName,
+			&i.Bio,
+			&i.CountryCode,
+			&i.AvatarKey,
+			&i.MainEvent,
+			&i.WcaID,
+			pq.Array(&i.SocialLinks),
+			&i.IsPrivate,
 		); err != nil {
 			return nil, err
 		}
@@ -118,11 +235,101 @@ func (q *Queries) GetUsersByUsername(ctx context.Context, arg GetUsersByUsername
 	return items, nil
 }
 
+const getUsersDeletedBefore = `-- name: GetUsersDeletedBefore :many
+SELECT id FROM users
+WHERE deleted_at < $1
+`
+
+func (q *Queries) GetUsersDeletedBefore(ctx context.Context, deletedBefore sql.NullTime) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getUsersDeletedBefore, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeUser = `-- name: PurgeUser :execrows
+DELETE FROM users
+WHERE id = $1 AND purging_at IS NOT NULL
+`
+
+func (q *Queries) PurgeUser(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreUser = `-- name: RestoreUser :one
+UPDATE users
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at > $2 AND purging_at IS NULL
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
+`
+
+type RestoreUserParams struct {
+	ID           uuid.UUID    `json:"id"`
+	DeletedAfter sql.NullTime `json:"deleted_after"`
+}
+
+func (q *Queries) RestoreUser(ctx context.Context, arg RestoreUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, restoreUser, arg.ID, arg.DeletedAfter)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const softDeleteUser = `-- name: SoftDeleteUser :exec
+UPDATE users
+SET deleted_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) SoftDeleteUser(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, softDeleteUser, id)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET username = $1, password_hash = $2
 WHERE id = $3
-RETURNING id, username, password_hash, email, created_at
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 `
 
 type UpdateUserParams struct {
@@ -140,15 +347,66 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.PasswordHash,
 		&i.Email,
 		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const updateUsersAvatar = `-- name: UpdateUsersAvatar :one
+UPDATE users
+SET avatar_key = $1
+WHERE id = $2
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
+`
+
+type UpdateUsersAvatarParams struct {
+	AvatarKey string    `json:"avatar_key"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateUsersAvatar(ctx context.Context, arg UpdateUsersAvatarParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUsersAvatar, arg.AvatarKey, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
 	)
 	return i, err
 }
 
 const updateUsersPassword = `-- name: UpdateUsersPassword :one
 UPDATE users
-SET password_hash = $1
+SET password_hash = $1, password_changed_at = now()
 WHERE id = $2
-RETURNING id, username, password_hash, email, created_at
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 `
 
 type UpdateUsersPasswordParams struct {
@@ -165,6 +423,146 @@ func (q *Queries) UpdateUsersPassword(ctx context.Context, arg UpdateUsersPasswo
 		&i.PasswordHash,
 		&i.Email,
 		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const updateUsersPrivacy = `-- name: UpdateUsersPrivacy :one
+UPDATE users
+SET is_private = $1
+WHERE id = $2
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
+`
+
+type UpdateUsersPrivacyParams struct {
+	IsPrivate bool      `json:"is_private"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateUsersPrivacy(ctx context.Context, arg UpdateUsersPrivacyParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUsersPrivacy, arg.IsPrivate, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const updateUsersProfile = `-- name: UpdateUsersProfile :one
+UPDATE users
+SET display_name = $1, bio = $2, country_code = $3, main_event = $4, wca_id = $5, social_links = $6
+WHERE id = $7
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
+`
+
+type UpdateUsersProfileParams struct {
+	DisplayName string    `json:"display_name"`
+	Bio         string    `json:"bio"`
+	CountryCode string    `json:"country_code"`
+	MainEvent   string    `json:"main_event"`
+	WcaID       string    `json:"wca_id"`
+	SocialLinks []string  `json:"social_links"`
+	ID          uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateUsersProfile(ctx context.Context, arg UpdateUsersProfileParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUsersProfile,
+		arg.DisplayName,
+		arg.Bio,
+		arg.CountryCode,
+		arg.MainEvent,
+		arg.WcaID,
+		pq.Array(arg.SocialLinks),
+		arg.ID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const updateUsersRole = `-- name: UpdateUsersRole :one
+UPDATE users
+SET role = $1
+WHERE id = $2
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
+`
+
+type UpdateUsersRoleParams struct {
+	Role string    `json:"role"`
+	ID   uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateUsersRole(ctx context.Context, arg UpdateUsersRoleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUsersRole, arg.Role, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
 	)
 	return i, err
 }
@@ -173,7 +571,7 @@ const updateUsersUsername = `-- name: UpdateUsersUsername :one
 UPDATE users
 SET username = $1
 WHERE id = $2
-RETURNING id, username, password_hash, email, created_at
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
 `
 
 type UpdateUsersUsernameParams struct {
@@ -190,6 +588,52 @@ func (q *Queries) UpdateUsersUsername(ctx context.Context, arg UpdateUsersUserna
 		&i.PasswordHash,
 		&i.Email,
 		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const verifyUsersEmail = `-- name: VerifyUsersEmail :one
+UPDATE users
+SET is_verified = true
+WHERE id = $1
+RETURNING id, username, password_hash, email, created_at, is_verified, password_changed_at, role, deleted_at, purging_at, display_name, bio, country_code, avatar_key, main_event, wca_id, social_links, is_private
+`
+
+func (q *Queries) VerifyUsersEmail(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, verifyUsersEmail, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsVerified,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.PurgingAt,
+		&i.DisplayName,
+		&i.Bio,
+		&i.CountryCode,
+		&i.AvatarKey,
+		&i.MainEvent,
+		&i.WcaID,
+		pq.Array(&i.SocialLinks),
+		&i.IsPrivate,
 	)
 	return i, err
 }
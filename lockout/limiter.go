@@ -0,0 +1,82 @@
+package lockout
+
+import (
+	"context"
+	"time"
+)
+
+type Policy struct {
+	// failures allowed before the key gets locked for the first time
+	MaxAttempts int
+	// the first lockout lasts BaseLockout, and every further failure doubles it up to MaxLockout
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+	// failures older than this are forgotten
+	ResetAfter time.Duration
+}
+
+func (policy Policy) LockoutFor(failures int) time.Duration {
+	if failures < policy.MaxAttempts {
+		return 0
+	}
+
+	lockout := policy.BaseLockout
+	for i := policy.MaxAttempts; i < failures; i++ {
+		lockout *= 2
+		if lockout >= policy.MaxLockout {
+			return policy.MaxLockout
+		}
+	}
+
+	return lockout
+}
+
+type Limiter struct {
+	store  Store
+	policy Policy
+	now    func() time.Time
+}
+
+func NewLimiter(store Store, policy Policy) *Limiter {
+	return &Limiter{
+		store:  store,
+		policy: policy,
+		now:    time.Now,
+	}
+}
+
+// RetryAfter returns how long the key is still locked for, or zero if it isn't
+func (limiter *Limiter) RetryAfter(ctx context.Context, key string) (time.Duration, error) {
+	lockedUntil, err := limiter.store.LockedUntil(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	retryAfter := lockedUntil.Sub(limiter.now())
+	if retryAfter < 0 {
+		return 0, nil
+	}
+
+	return retryAfter, nil
+}
+
+// Fail records a failed attempt and locks the key if it went over the limit
+func (limiter *Limiter) Fail(ctx context.Context, key string) error {
+	now := limiter.now()
+
+	failures, err := limiter.store.RecordFailure(ctx, key, now, now.Add(-limiter.policy.ResetAfter))
+	if err != nil {
+		return err
+	}
+
+	lockout := limiter.policy.LockoutFor(failures)
+	if lockout == 0 {
+		return nil
+	}
+
+	return limiter.store.Lock(ctx, key, now.Add(lockout))
+}
+
+func (limiter *Limiter) Succeed(ctx context.Context, key string) error {
+	return limiter.store.Reset(ctx, key)
+}
@@ -0,0 +1,110 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = Policy{
+	MaxAttempts: 3,
+	BaseLockout: time.Minute,
+	MaxLockout:  10 * time.Minute,
+	ResetAfter:  time.Hour,
+}
+
+func newTestLimiter(now *time.Time) *Limiter {
+	limiter := NewLimiter(NewMemoryStore(), testPolicy)
+	limiter.now = func() time.Time {
+		return *now
+	}
+	return limiter
+}
+
+func TestLockoutFor(t *testing.T) {
+	require.Zero(t, testPolicy.LockoutFor(1))
+	require.Zero(t, testPolicy.LockoutFor(2))
+	require.Equal(t, time.Minute, testPolicy.LockoutFor(3))
+	require.Equal(t, 2*time.Minute, testPolicy.LockoutFor(4))
+	require.Equal(t, 4*time.Minute, testPolicy.LockoutFor(5))
+	require.Equal(t, 8*time.Minute, testPolicy.LockoutFor(6))
+	require.Equal(t, 10*time.Minute, testPolicy.LockoutFor(7))
+	require.Equal(t, 10*time.Minute, testPolicy.LockoutFor(100))
+}
+
+func TestLimiterLocksAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	limiter := newTestLimiter(&now)
+
+	for i := 0; i < testPolicy.MaxAttempts-1; i++ {
+		require.NoError(t, limiter.Fail(ctx, "darko"))
+
+		retryAfter, err := limiter.RetryAfter(ctx, "darko")
+		require.NoError(t, err)
+		require.Zero(t, retryAfter)
+	}
+
+	require.NoError(t, limiter.Fail(ctx, "darko"))
+
+	retryAfter, err := limiter.RetryAfter(ctx, "darko")
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, retryAfter)
+
+	retryAfter, err = limiter.RetryAfter(ctx, "someone else")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	now = now.Add(time.Minute)
+
+	retryAfter, err = limiter.RetryAfter(ctx, "darko")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	// the next failure after the lockout doubles it
+	require.NoError(t, limiter.Fail(ctx, "darko"))
+
+	retryAfter, err = limiter.RetryAfter(ctx, "darko")
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Minute, retryAfter)
+}
+
+func TestLimiterSucceedResets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	limiter := newTestLimiter(&now)
+
+	for i := 0; i < testPolicy.MaxAttempts; i++ {
+		require.NoError(t, limiter.Fail(ctx, "darko"))
+	}
+	require.NoError(t, limiter.Succeed(ctx, "darko"))
+
+	retryAfter, err := limiter.RetryAfter(ctx, "darko")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	require.NoError(t, limiter.Fail(ctx, "darko"))
+
+	retryAfter, err = limiter.RetryAfter(ctx, "darko")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+}
+
+func TestLimiterForgetsOldFailures(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	limiter := newTestLimiter(&now)
+
+	for i := 0; i < testPolicy.MaxAttempts-1; i++ {
+		require.NoError(t, limiter.Fail(ctx, "darko"))
+	}
+
+	now = now.Add(testPolicy.ResetAfter + time.Second)
+	require.NoError(t, limiter.Fail(ctx, "darko"))
+
+	retryAfter, err := limiter.RetryAfter(ctx, "darko")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+}
@@ -0,0 +1,96 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// writes between two sweeps of stale entries, so the map can't grow forever
+const memoryStoreSweepInterval = 1000
+
+type memoryEntry struct {
+	failures      int
+	lastFailureAt time.Time
+	lockedUntil   time.Time
+}
+
+type MemoryStore struct {
+	mutex   sync.Mutex
+	entries map[string]*memoryEntry
+	writes  int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*memoryEntry),
+	}
+}
+
+func (store *MemoryStore) RecordFailure(_ context.Context, key string, failedAt time.Time, resetBefore time.Time) (int, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.writes++
+	if store.writes%memoryStoreSweepInterval == 0 {
+		store.sweep(failedAt, resetBefore)
+	}
+
+	entry, ok := store.entries[key]
+	if !ok {
+		entry = &memoryEntry{}
+		store.entries[key] = entry
+	}
+
+	if entry.lastFailureAt.Before(resetBefore) {
+		entry.failures = 0
+	}
+
+	entry.failures++
+	entry.lastFailureAt = failedAt
+
+	return entry.failures, nil
+}
+
+func (store *MemoryStore) LockedUntil(_ context.Context, key string) (time.Time, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entry, ok := store.entries[key]
+	if !ok {
+		return time.Time{}, nil
+	}
+
+	return entry.lockedUntil, nil
+}
+
+func (store *MemoryStore) Lock(_ context.Context, key string, until time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entry, ok := store.entries[key]
+	if !ok {
+		entry = &memoryEntry{}
+		store.entries[key] = entry
+	}
+	entry.lockedUntil = until
+
+	return nil
+}
+
+func (store *MemoryStore) Reset(_ context.Context, key string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.entries, key)
+
+	return nil
+}
+
+func (store *MemoryStore) sweep(now time.Time, resetBefore time.Time) {
+	for key, entry := range store.entries {
+		if entry.lastFailureAt.Before(resetBefore) && entry.lockedUntil.Before(now) {
+			delete(store.entries, key)
+		}
+	}
+}
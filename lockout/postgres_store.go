@@ -0,0 +1,52 @@
+package lockout
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+)
+
+// PostgresStore shares the counters between every instance of the server
+type PostgresStore struct {
+	queries *database.Queries
+}
+
+func NewPostgresStore(queries *database.Queries) *PostgresStore {
+	return &PostgresStore{
+		queries: queries,
+	}
+}
+
+func (store *PostgresStore) RecordFailure(ctx context.Context, key string, failedAt time.Time, resetBefore time.Time) (int, error) {
+	failures, err := store.queries.RecordLoginFailure(ctx, database.RecordLoginFailureParams{
+		Key:         key,
+		FailedAt:    failedAt,
+		ResetBefore: resetBefore,
+	})
+	return int(failures), err
+}
+
+func (store *PostgresStore) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	attempt, err := store.queries.GetLoginAttempt(ctx, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	return attempt.LockedUntil.Time, nil
+}
+
+func (store *PostgresStore) Lock(ctx context.Context, key string, until time.Time) error {
+	return store.queries.LockLoginAttempt(ctx, database.LockLoginAttemptParams{
+		Key:         key,
+		LockedUntil: sql.NullTime{Time: until, Valid: true},
+	})
+}
+
+func (store *PostgresStore) Reset(ctx context.Context, key string) error {
+	return store.queries.DeleteLoginAttempt(ctx, key)
+}
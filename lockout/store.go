@@ -0,0 +1,35 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	database "github.com/dqrk0jeste/letscube-backend/database/sqlc"
+	"github.com/dqrk0jeste/letscube-backend/util"
+)
+
+// Store keeps failed attempt counters. implementations have to make
+// RecordFailure atomic, otherwise parallel requests could slip past the limit.
+type Store interface {
+	// RecordFailure increments the counter for the key and returns the new value.
+	// counters whose last failure happened before resetBefore start over from one.
+	RecordFailure(ctx context.Context, key string, failedAt time.Time, resetBefore time.Time) (int, error)
+	// LockedUntil returns the zero time if the key is not locked
+	LockedUntil(ctx context.Context, key string) (time.Time, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+// NewStore picks the implementation from LOGIN_ATTEMPT_STORE. the memory store
+// is fine for a single instance, but it forgets everything on restart
+func NewStore(config util.Config, store *database.Store) (Store, error) {
+	switch config.LoginAttemptStore {
+	case "postgres":
+		return NewPostgresStore(store.Queries), nil
+	case "memory", "":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported login attempt store %s", config.LoginAttemptStore)
+	}
+}
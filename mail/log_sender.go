@@ -0,0 +1,52 @@
+package mail
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogSender doesn't deliver anything, it appends every email to a file (or the
+// standard logger if no path is set) so tokens can be picked up during development
+type LogSender struct {
+	mutex sync.Mutex
+	path  string
+	from  string
+}
+
+func NewLogSender(path string, from string) *LogSender {
+	return &LogSender{
+		path: path,
+		from: from,
+	}
+}
+
+func (sender *LogSender) Send(to string, subject string, body string) error {
+	entry := fmt.Sprintf(
+		"date: %s\nfrom: %s\nto: %s\nsubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC3339),
+		sender.from,
+		to,
+		subject,
+		body,
+	)
+
+	if sender.path == "" {
+		log.Print(entry)
+		return nil
+	}
+
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+
+	file, err := os.OpenFile(sender.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(entry)
+	return err
+}
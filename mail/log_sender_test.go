@@ -0,0 +1,26 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogSender(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	sender := NewLogSender(path, "noreply@letscube.com")
+
+	err := sender.Send("darko@letscube.com", "first subject", "first body")
+	require.NoError(t, err)
+
+	err = sender.Send("darko@letscube.com", "second subject", "second body")
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "to: darko@letscube.com")
+	require.Contains(t, string(content), "subject: first subject\n\nfirst body")
+	require.Contains(t, string(content), "subject: second subject\n\nsecond body")
+}
@@ -0,0 +1,30 @@
+package mail
+
+import (
+	"fmt"
+
+	"github.com/dqrk0jeste/letscube-backend/util"
+)
+
+type Sender interface {
+	Send(to string, subject string, body string) error
+}
+
+// NewSender picks the implementation from MAIL_SENDER, defaulting to the log
+// sender so local development doesn't need an smtp server
+func NewSender(config util.Config) (Sender, error) {
+	switch config.MailSender {
+	case "smtp":
+		return NewSMTPSender(
+			config.SMTPHost,
+			config.SMTPPort,
+			config.SMTPUsername,
+			config.SMTPPassword,
+			config.MailFrom,
+		), nil
+	case "log", "":
+		return NewLogSender(config.MailLogPath, config.MailFrom), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail sender %s", config.MailSender)
+	}
+}
@@ -21,7 +21,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	database := database.New(connection)
+	database := database.NewStore(connection)
 
 	server, err := api.CreateServer(config, database)
 	if err != nil {
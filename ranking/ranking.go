@@ -0,0 +1,169 @@
+package ranking
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Weights struct {
+	// a post loses half of its score every RecencyHalfLife
+	RecencyHalfLife time.Duration
+	// every signal adds weight * ln(1 + count), so the first few reactions or
+	// comments on a post matter more than the hundredth
+	Reactions float64
+	Comments  float64
+	// affinity is how many times the viewer reacted to or commented on the
+	// author's posts
+	Affinity float64
+	// posts from users the viewer doesn't follow have their score multiplied by
+	// DiscoveryFactor, and can take at most DiscoveryShare of the feed
+	DiscoveryFactor float64
+	DiscoveryShare  float64
+}
+
+type Candidate struct {
+	PostID    uuid.UUID
+	CreatedAt time.Time
+	Reactions int64
+	Comments  int64
+	Affinity  int64
+	Followed  bool
+}
+
+func (weights Weights) Score(candidate Candidate, now time.Time) float64 {
+	score := 1 +
+		weights.Reactions*math.Log1p(float64(candidate.Reactions)) +
+		weights.Comments*math.Log1p(float64(candidate.Comments)) +
+		weights.Affinity*math.Log1p(float64(candidate.Affinity))
+
+	age := now.Sub(candidate.CreatedAt)
+	if age > 0 && weights.RecencyHalfLife > 0 {
+		score *= math.Exp2(-float64(age) / float64(weights.RecencyHalfLife))
+	}
+
+	if !candidate.Followed {
+		score *= weights.DiscoveryFactor
+	}
+
+	return score
+}
+
+type scoredCandidate struct {
+	Candidate
+	score float64
+}
+
+// Mark is the last post a page took from one of the two streams, the next
+// page continues with the posts that sort after it
+type Mark struct {
+	Score     float64   `json:"s"`
+	CreatedAt time.Time `json:"t"`
+	PostID    uuid.UUID `json:"i"`
+}
+
+// Position is how far a client got through a ranked feed. Ranked and
+// DiscoveredUsed keep the discovery share going across pages
+type Position struct {
+	Followed       *Mark `json:"f,omitempty"`
+	Discovered     *Mark `json:"d,omitempty"`
+	Ranked         int   `json:"n,omitempty"`
+	DiscoveredUsed int   `json:"dn,omitempty"`
+}
+
+// Rank orders the candidates from the best to the worst. posts from users the
+// viewer doesn't follow only get a slot while they stay under DiscoveryShare of
+// the feed so far, unless there is nothing else left to show
+func Rank(candidates []Candidate, weights Weights, now time.Time) []Candidate {
+	ranked, _ := RankFrom(candidates, weights, now, Position{}, len(candidates))
+	return ranked
+}
+
+// RankFrom ranks at most limit candidates that come after the position, and
+// returns where it stopped. a post is skipped once its stream went past its
+// score, so one that gains reactions between two pages is never shown twice
+func RankFrom(candidates []Candidate, weights Weights, now time.Time, from Position, limit int) ([]Candidate, Position) {
+	followed := make([]scoredCandidate, 0, len(candidates))
+	discovered := make([]scoredCandidate, 0)
+
+	for _, candidate := range candidates {
+		scored := scoredCandidate{
+			Candidate: candidate,
+			score:     weights.Score(candidate, now),
+		}
+		if candidate.Followed {
+			if scored.after(from.Followed) {
+				followed = append(followed, scored)
+			}
+		} else if scored.after(from.Discovered) {
+			discovered = append(discovered, scored)
+		}
+	}
+
+	sortByScore(followed)
+	sortByScore(discovered)
+
+	ranked := make([]Candidate, 0, min(limit, len(followed)+len(discovered)))
+	position := from
+
+	for len(ranked) < limit && (len(followed) > 0 || len(discovered) > 0) {
+		takeDiscovered := len(followed) == 0
+		if !takeDiscovered && len(discovered) > 0 {
+			allowed := float64(position.DiscoveredUsed+1) <= weights.DiscoveryShare*float64(position.Ranked+1)
+			takeDiscovered = allowed && discovered[0].score > followed[0].score
+		}
+
+		if takeDiscovered {
+			ranked = append(ranked, discovered[0].Candidate)
+			position.Discovered = discovered[0].mark()
+			discovered = discovered[1:]
+			position.DiscoveredUsed++
+		} else {
+			ranked = append(ranked, followed[0].Candidate)
+			position.Followed = followed[0].mark()
+			followed = followed[1:]
+		}
+		position.Ranked++
+	}
+
+	return ranked, position
+}
+
+func (candidate scoredCandidate) mark() *Mark {
+	return &Mark{
+		Score:     candidate.score,
+		CreatedAt: candidate.CreatedAt,
+		PostID:    candidate.PostID,
+	}
+}
+
+// after tells if the candidate sorts after the mark, in the same order
+// sortByScore uses
+func (candidate scoredCandidate) after(mark *Mark) bool {
+	if mark == nil {
+		return true
+	}
+	if candidate.score != mark.Score {
+		return candidate.score < mark.Score
+	}
+	if !candidate.CreatedAt.Equal(mark.CreatedAt) {
+		return candidate.CreatedAt.Before(mark.CreatedAt)
+	}
+	return candidate.PostID.String() < mark.PostID.String()
+}
+
+// ties go to the newer post, and then to the id so the order never changes
+// between two calls with the same input
+func sortByScore(candidates []scoredCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if !candidates[i].CreatedAt.Equal(candidates[j].CreatedAt) {
+			return candidates[i].CreatedAt.After(candidates[j].CreatedAt)
+		}
+		return candidates[i].PostID.String() > candidates[j].PostID.String()
+	})
+}
@@ -0,0 +1,202 @@
+package ranking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testWeights = Weights{
+	RecencyHalfLife: 6 * time.Hour,
+	Reactions:       1,
+	Comments:        1.5,
+	Affinity:        2,
+	DiscoveryFactor: 0.5,
+	DiscoveryShare:  0.25,
+}
+
+func newCandidate(now time.Time, age time.Duration, followed bool) Candidate {
+	return Candidate{
+		PostID:    uuid.New(),
+		CreatedAt: now.Add(-age),
+		Followed:  followed,
+	}
+}
+
+func TestScoreDecaysWithAge(t *testing.T) {
+	now := time.Now()
+
+	fresh := testWeights.Score(newCandidate(now, 0, true), now)
+	halfLife := testWeights.Score(newCandidate(now, 6*time.Hour, true), now)
+	day := testWeights.Score(newCandidate(now, 24*time.Hour, true), now)
+
+	require.InDelta(t, 1, fresh, 1e-9)
+	require.InDelta(t, fresh/2, halfLife, 1e-9)
+	require.InDelta(t, fresh/16, day, 1e-9)
+
+	// posts from the future, clock skew between servers, are not boosted
+	require.InDelta(t, fresh, testWeights.Score(newCandidate(now, -time.Hour, true), now), 1e-9)
+}
+
+func TestScoreRewardsEngagement(t *testing.T) {
+	now := time.Now()
+	base := newCandidate(now, time.Hour, true)
+
+	reacted := base
+	reacted.Reactions = 10
+
+	commented := base
+	commented.Comments = 10
+
+	familiar := base
+	familiar.Affinity = 10
+
+	require.Greater(t, testWeights.Score(reacted, now), testWeights.Score(base, now))
+	require.Greater(t, testWeights.Score(commented, now), testWeights.Score(reacted, now))
+	require.Greater(t, testWeights.Score(familiar, now), testWeights.Score(commented, now))
+
+	// a popular old post still loses to a fresh one eventually
+	old := newCandidate(now, 72*time.Hour, true)
+	old.Reactions = 1000
+	require.Less(t, testWeights.Score(old, now), testWeights.Score(base, now))
+}
+
+func TestScorePenalizesDiscovery(t *testing.T) {
+	now := time.Now()
+
+	followed := newCandidate(now, time.Hour, true)
+	discovered := followed
+	discovered.Followed = false
+
+	require.InDelta(t, testWeights.Score(followed, now)*0.5, testWeights.Score(discovered, now), 1e-9)
+}
+
+func TestRankOrdersByScore(t *testing.T) {
+	now := time.Now()
+
+	oldest := newCandidate(now, 10*time.Hour, true)
+	newest := newCandidate(now, time.Hour, true)
+	popular := newCandidate(now, 5*time.Hour, true)
+	popular.Reactions = 50
+
+	ranked := Rank([]Candidate{oldest, newest, popular}, testWeights, now)
+
+	require.Equal(t, []uuid.UUID{popular.PostID, newest.PostID, oldest.PostID}, postIDs(ranked))
+}
+
+func TestRankBreaksTiesByTime(t *testing.T) {
+	now := time.Now()
+
+	older := newCandidate(now, time.Hour, true)
+	newer := newCandidate(now, time.Hour-time.Nanosecond, true)
+	// without a half-life every post with no engagement scores the same
+	weights := testWeights
+	weights.RecencyHalfLife = 0
+
+	ranked := Rank([]Candidate{older, newer}, weights, now)
+
+	require.Equal(t, []uuid.UUID{newer.PostID, older.PostID}, postIDs(ranked))
+}
+
+func TestRankLimitsDiscovery(t *testing.T) {
+	now := time.Now()
+
+	candidates := make([]Candidate, 0)
+	for i := 0; i < 8; i++ {
+		candidates = append(candidates, newCandidate(now, 10*time.Hour+time.Duration(i)*time.Minute, true))
+	}
+	for i := 0; i < 4; i++ {
+		// viral posts that would all beat the followed ones on score alone
+		discovered := newCandidate(now, time.Duration(i)*time.Minute, false)
+		discovered.Reactions = 1000
+		candidates = append(candidates, discovered)
+	}
+
+	ranked := Rank(candidates, testWeights, now)
+	require.Len(t, ranked, len(candidates))
+
+	// the share holds while there are followed posts left, after that the
+	// rest of the discovered ones fill the tail
+	discovered := 0
+	for i, candidate := range ranked[:8+2] {
+		if !candidate.Followed {
+			discovered++
+		}
+		require.LessOrEqual(t, float64(discovered), testWeights.DiscoveryShare*float64(i+1))
+	}
+
+	require.True(t, ranked[8+2-1].Followed)
+	require.False(t, ranked[len(ranked)-1].Followed)
+	require.False(t, ranked[len(ranked)-2].Followed)
+}
+
+func TestRankFillsWithDiscoveryWhenNothingIsFollowed(t *testing.T) {
+	now := time.Now()
+
+	candidates := []Candidate{
+		newCandidate(now, time.Hour, false),
+		newCandidate(now, 2*time.Hour, false),
+		newCandidate(now, 3*time.Hour, false),
+	}
+
+	ranked := Rank(candidates, testWeights, now)
+
+	require.Equal(t, postIDs(candidates), postIDs(ranked))
+}
+
+func postIDs(candidates []Candidate) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.PostID)
+	}
+	return ids
+}
+
+func TestRankFromPagesLikeRank(t *testing.T) {
+	now := time.Now()
+
+	candidates := make([]Candidate, 0)
+	for i := 0; i < 9; i++ {
+		candidates = append(candidates, newCandidate(now, time.Duration(i)*time.Hour, true))
+	}
+	for i := 0; i < 4; i++ {
+		discovered := newCandidate(now, time.Duration(i)*time.Minute, false)
+		discovered.Reactions = 1000
+		candidates = append(candidates, discovered)
+	}
+
+	paged := make([]Candidate, 0, len(candidates))
+	position := Position{}
+	for {
+		var page []Candidate
+		page, position = RankFrom(candidates, testWeights, now, position, 4)
+		if len(page) == 0 {
+			break
+		}
+		require.LessOrEqual(t, len(page), 4)
+		paged = append(paged, page...)
+	}
+
+	require.Equal(t, postIDs(Rank(candidates, testWeights, now)), postIDs(paged))
+}
+
+func TestRankFromSkipsPostsThatMovedUp(t *testing.T) {
+	now := time.Now()
+
+	candidates := []Candidate{
+		newCandidate(now, time.Hour, true),
+		newCandidate(now, 2*time.Hour, true),
+		newCandidate(now, 3*time.Hour, true),
+	}
+
+	first, position := RankFrom(candidates, testWeights, now, Position{}, 2)
+	require.Equal(t, postIDs(candidates[:2]), postIDs(first))
+
+	// the first post got popular after the first page was served
+	candidates[0].Reactions = 100
+
+	second, _ := RankFrom(candidates, testWeights, now, position, 2)
+	require.Equal(t, postIDs(candidates[2:]), postIDs(second))
+}
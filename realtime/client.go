@@ -0,0 +1,102 @@
+package realtime
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 512
+	sendBufferSize = 16
+)
+
+type Client struct {
+	hub        *Hub
+	userID     uuid.UUID
+	connection *websocket.Conn
+	send       chan []byte
+	expiresAt  time.Time
+}
+
+// NewClient wraps an already upgraded connection. the connection is closed once
+// expiresAt passes, so a client can't outlive the access token it connected with.
+func NewClient(hub *Hub, userID uuid.UUID, connection *websocket.Conn, expiresAt time.Time) *Client {
+	return &Client{
+		hub:        hub,
+		userID:     userID,
+		connection: connection,
+		send:       make(chan []byte, sendBufferSize),
+		expiresAt:  expiresAt,
+	}
+}
+
+// Run registers the client with the hub and blocks until the connection is closed.
+func (client *Client) Run() {
+	client.hub.register(client)
+
+	go client.writePump()
+	client.readPump()
+}
+
+// clients are not expected to send anything, we only read to process pongs and
+// to notice when the connection goes away.
+func (client *Client) readPump() {
+	defer func() {
+		client.hub.unregister(client)
+		client.connection.Close()
+	}()
+
+	client.connection.SetReadLimit(maxMessageSize)
+	client.connection.SetReadDeadline(time.Now().Add(pongWait))
+	client.connection.SetPongHandler(func(string) error {
+		return client.connection.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := client.connection.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (client *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	expiration := time.NewTimer(time.Until(client.expiresAt))
+	defer func() {
+		ticker.Stop()
+		expiration.Stop()
+		client.connection.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-client.send:
+			client.connection.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				client.connection.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+
+			if err := client.connection.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			client.connection.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := client.connection.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-expiration.C:
+			client.connection.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "token expired"),
+				time.Now().Add(writeWait),
+			)
+			return
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package realtime
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventNewMessage  = "new_message"
+	EventNewFollower = "new_follower"
+	EventNewComment  = "new_comment"
+
+	EventFollowRequest         = "follow_request"
+	EventFollowRequestApproved = "follow_request_approved"
+
+	EventDataExportReady = "data_export_ready"
+)
+
+type Event struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// Hub keeps track of every open connection per user, since one user can be
+// connected from more than one device at the same time.
+type Hub struct {
+	mutex   sync.RWMutex
+	clients map[uuid.UUID]map[*Client]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		clients: make(map[uuid.UUID]map[*Client]struct{}),
+	}
+}
+
+func (hub *Hub) register(client *Client) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	userClients, ok := hub.clients[client.userID]
+	if !ok {
+		userClients = make(map[*Client]struct{})
+		hub.clients[client.userID] = userClients
+	}
+	userClients[client] = struct{}{}
+}
+
+func (hub *Hub) unregister(client *Client) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	userClients, ok := hub.clients[client.userID]
+	if !ok {
+		return
+	}
+
+	if _, ok := userClients[client]; ok {
+		delete(userClients, client)
+		close(client.send)
+	}
+
+	if len(userClients) == 0 {
+		delete(hub.clients, client.userID)
+	}
+}
+
+// SendToUser pushes the event to every connection of the user. it never blocks,
+// clients that can't keep up are disconnected and are expected to reconnect.
+func (hub *Hub) SendToUser(userID uuid.UUID, event Event) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		log.Println("error marshaling websocket event:", err)
+		return
+	}
+
+	hub.mutex.RLock()
+	var slowClients []*Client
+	for client := range hub.clients[userID] {
+		select {
+		case client.send <- message:
+		default:
+			slowClients = append(slowClients, client)
+		}
+	}
+	hub.mutex.RUnlock()
+
+	for _, client := range slowClients {
+		hub.unregister(client)
+	}
+}
+
+func (hub *Hub) IsOnline(userID uuid.UUID) bool {
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+
+	return len(hub.clients[userID]) > 0
+}
@@ -0,0 +1,76 @@
+package realtime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(hub *Hub, userID uuid.UUID, bufferSize int) *Client {
+	return &Client{
+		hub:       hub,
+		userID:    userID,
+		send:      make(chan []byte, bufferSize),
+		expiresAt: time.Now().Add(time.Minute),
+	}
+}
+
+func TestSendToUser(t *testing.T) {
+	hub := NewHub()
+	userID := uuid.New()
+
+	first := newTestClient(hub, userID, sendBufferSize)
+	second := newTestClient(hub, userID, sendBufferSize)
+	other := newTestClient(hub, uuid.New(), sendBufferSize)
+
+	hub.register(first)
+	hub.register(second)
+	hub.register(other)
+	require.True(t, hub.IsOnline(userID))
+
+	hub.SendToUser(userID, Event{Type: EventNewFollower, Data: "data"})
+
+	for _, client := range []*Client{first, second} {
+		require.Len(t, client.send, 1)
+
+		var event Event
+		require.NoError(t, json.Unmarshal(<-client.send, &event))
+		require.Equal(t, EventNewFollower, event.Type)
+		require.Equal(t, "data", event.Data)
+	}
+	require.Empty(t, other.send)
+}
+
+func TestUnregister(t *testing.T) {
+	hub := NewHub()
+	userID := uuid.New()
+
+	client := newTestClient(hub, userID, sendBufferSize)
+	hub.register(client)
+	hub.unregister(client)
+
+	require.False(t, hub.IsOnline(userID))
+
+	_, ok := <-client.send
+	require.False(t, ok)
+
+	// unregistering twice must not close the channel again
+	hub.unregister(client)
+}
+
+func TestSlowClientIsDropped(t *testing.T) {
+	hub := NewHub()
+	userID := uuid.New()
+
+	client := newTestClient(hub, userID, 1)
+	hub.register(client)
+
+	hub.SendToUser(userID, Event{Type: EventNewMessage})
+	require.True(t, hub.IsOnline(userID))
+
+	hub.SendToUser(userID, Event{Type: EventNewMessage})
+	require.False(t, hub.IsOnline(userID))
+}
@@ -71,3 +71,42 @@ func (controller *S3Controller) Delete(context context.Context, nameOfTheFile st
 
 	return err
 }
+
+// UploadObject stores the body as it is, unlike Upload which expects an image
+func (controller *S3Controller) UploadObject(
+	context context.Context,
+	body io.Reader,
+	nameToSaveAs string,
+	contentType string,
+) (*manager.UploadOutput, error) {
+	return controller.uploader.Upload(context, &s3.PutObjectInput{
+		Bucket:      aws.String("letscube"),
+		Key:         aws.String(nameToSaveAs),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+}
+
+// Download returns the body of the object, the caller has to close it
+func (controller *S3Controller) Download(context context.Context, nameOfTheFile string) (io.ReadCloser, error) {
+	output, err := controller.client.GetObject(context, &s3.GetObjectInput{
+		Bucket: aws.String("letscube"),
+		Key:    aws.String(nameOfTheFile),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+// Copy duplicates an object inside the bucket, the source stays where it is
+func (controller *S3Controller) Copy(context context.Context, source string, destination string) error {
+	_, err := controller.client.CopyObject(context, &s3.CopyObjectInput{
+		Bucket:     aws.String("letscube"),
+		CopySource: aws.String("letscube/" + source),
+		Key:        aws.String(destination),
+	})
+
+	return err
+}
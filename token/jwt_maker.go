@@ -0,0 +1,116 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const jwtEdDSAAlgorithm = "EdDSA"
+
+// jwtClaims carries the payload as it is, plus the registered claims so
+// services that only understand standard jwts can still read the token
+type jwtClaims struct {
+	Payload
+	jwt.RegisteredClaims
+}
+
+// JWTMaker creates either HS256 tokens with a symmetric keyring or EdDSA tokens
+// with an ed25519 keyring. the key id goes into the kid header
+type JWTMaker struct {
+	method        jwt.SigningMethod
+	keyring       *Keyring
+	publicKeyring *PublicKeyring
+}
+
+func NewJWTMakerHS256(keyring *Keyring) *JWTMaker {
+	return &JWTMaker{
+		method:  jwt.SigningMethodHS256,
+		keyring: keyring,
+	}
+}
+
+func NewJWTMakerEdDSA(keyring *PublicKeyring) *JWTMaker {
+	return &JWTMaker{
+		method:        jwt.SigningMethodEdDSA,
+		publicKeyring: keyring,
+	}
+}
+
+func (maker *JWTMaker) CreateToken(userID uuid.UUID, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID, role, tokenType, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := jwt.NewWithClaims(maker.method, jwtClaims{
+		Payload: *payload,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        payload.ID.String(),
+			Subject:   payload.UserID.String(),
+			IssuedAt:  jwt.NewNumericDate(payload.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
+		},
+	})
+
+	var keyID string
+	var key interface{}
+	if maker.keyring != nil {
+		keyID, key = maker.keyring.activeKey()
+	} else {
+		keyID, key = maker.publicKeyring.activeKey()
+	}
+
+	if keyID != legacyKeyID {
+		token.Header["kid"] = keyID
+	}
+
+	signed, err := token.SignedString(key)
+	return signed, payload, err
+}
+
+func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
+	claims := &jwtClaims{}
+
+	// only the configured algorithm is accepted, otherwise a token could pick
+	// "none" or sign with the public key as an hmac secret
+	_, err := jwt.ParseWithClaims(token, claims, maker.key, jwt.WithValidMethods([]string{maker.method.Alg()}))
+	if err != nil {
+		if errors.Is(err, ErrUnknownKey) {
+			return nil, ErrUnknownKey
+		}
+		return nil, err
+	}
+
+	payload := &claims.Payload
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (maker *JWTMaker) key(token *jwt.Token) (interface{}, error) {
+	keyID := legacyKeyID
+	if kid, ok := token.Header["kid"]; ok {
+		keyID, ok = kid.(string)
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+	}
+
+	if maker.keyring != nil {
+		return maker.keyring.key(keyID)
+	}
+	return maker.publicKeyring.publicKey(keyID)
+}
+
+// PublicKeys only has something to publish for EdDSA, an HS256 secret must never leave the server
+func (maker *JWTMaker) PublicKeys() []PublicKey {
+	if maker.publicKeyring == nil {
+		return nil
+	}
+	return maker.publicKeyring.publicKeys(jwtEdDSAAlgorithm)
+}
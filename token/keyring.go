@@ -0,0 +1,105 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aead/chacha20poly1305"
+	"github.com/dqrk0jeste/letscube-backend/util"
+)
+
+// legacyKeyID is the id of the key tokens without a footer were created with,
+// which is every token issued before the keyring existed
+const legacyKeyID = ""
+
+var ErrUnknownKey = errors.New("token was created with an unknown key")
+
+// Keyring holds every key a token can be verified with. new tokens are always
+// created with the active key, so rotating is a matter of adding a key, making
+// it active once every instance knows about it, and dropping the old one after
+// the longest token duration has passed.
+type Keyring struct {
+	keys        map[string][]byte
+	activeKeyID string
+}
+
+func NewKeyring(keys map[string]string, activeKeyID string) (*Keyring, error) {
+	keyring := &Keyring{
+		keys:        make(map[string][]byte, len(keys)),
+		activeKeyID: activeKeyID,
+	}
+
+	for id, key := range keys {
+		if len(key) != chacha20poly1305.KeySize {
+			return nil, fmt.Errorf("invalid size of key %q: must be exactly %d characters long", id, chacha20poly1305.KeySize)
+		}
+		keyring.keys[id] = []byte(key)
+	}
+
+	if _, ok := keyring.keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active key %q is not in the keyring", activeKeyID)
+	}
+
+	return keyring, nil
+}
+
+// NewKeyringFromConfig builds the keyring from TOKEN_KEYS and TOKEN_ACTIVE_KEY_ID.
+// without TOKEN_KEYS it falls back to the single TOKEN_SECRET, and with both set
+// TOKEN_SECRET is kept around to verify tokens issued before the switch.
+func NewKeyringFromConfig(config util.Config) (*Keyring, error) {
+	if config.TokenKeys == "" {
+		return NewKeyring(map[string]string{legacyKeyID: config.TokenSecret}, legacyKeyID)
+	}
+
+	keys, err := ParseKeys(config.TokenKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.TokenActiveKeyID == "" {
+		return nil, errors.New("TOKEN_ACTIVE_KEY_ID is required when TOKEN_KEYS is set")
+	}
+
+	if config.TokenSecret != "" {
+		keys[legacyKeyID] = config.TokenSecret
+	}
+
+	return NewKeyring(keys, config.TokenActiveKeyID)
+}
+
+// ParseKeys reads keys in the "id:secret,id:secret" format
+func ParseKeys(keys string) (map[string]string, error) {
+	parsed := make(map[string]string)
+
+	for _, entry := range strings.Split(keys, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, key, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid key entry %q: must be in the id:secret format", entry)
+		}
+		if _, ok := parsed[id]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", id)
+		}
+
+		parsed[id] = key
+	}
+
+	return parsed, nil
+}
+
+func (keyring *Keyring) activeKey() (string, []byte) {
+	return keyring.activeKeyID, keyring.keys[keyring.activeKeyID]
+}
+
+func (keyring *Keyring) key(id string) ([]byte, error) {
+	key, ok := keyring.keys[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
@@ -0,0 +1,94 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testKey1 = "dadsdfdsfsdfdsfsdfsdfsdffrkjmbdx"
+	testKey2 = "kjhgfdsaqwertzuiopmnbvcxylkjhgfd"
+)
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys("2024-01:" + testKey1 + ", 2024-02:" + testKey2 + ",")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"2024-01": testKey1,
+		"2024-02": testKey2,
+	}, keys)
+
+	_, err = ParseKeys(testKey1)
+	require.Error(t, err)
+
+	_, err = ParseKeys("a:" + testKey1 + ",a:" + testKey2)
+	require.Error(t, err)
+}
+
+func TestNewKeyring(t *testing.T) {
+	_, err := NewKeyring(map[string]string{"a": testKey1}, "b")
+	require.Error(t, err)
+
+	_, err = NewKeyring(map[string]string{"a": "too short"}, "a")
+	require.Error(t, err)
+}
+
+func TestPasetoMakerKeyRotation(t *testing.T) {
+	oldKeyring, err := NewKeyring(map[string]string{"a": testKey1}, "a")
+	require.NoError(t, err)
+	oldMaker := NewPasetoMakerFromKeyring(oldKeyring)
+
+	oldToken, _, err := oldMaker.CreateToken(uuid.New(), util.RoleUser, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	rotatedKeyring, err := NewKeyring(map[string]string{"a": testKey1, "b": testKey2}, "b")
+	require.NoError(t, err)
+	rotatedMaker := NewPasetoMakerFromKeyring(rotatedKeyring)
+
+	_, err = rotatedMaker.VerifyToken(oldToken)
+	require.NoError(t, err)
+
+	newToken, _, err := rotatedMaker.CreateToken(uuid.New(), util.RoleUser, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	_, err = oldMaker.VerifyToken(newToken)
+	require.ErrorIs(t, err, ErrUnknownKey)
+
+	retiredKeyring, err := NewKeyring(map[string]string{"b": testKey2}, "b")
+	require.NoError(t, err)
+	retiredMaker := NewPasetoMakerFromKeyring(retiredKeyring)
+
+	_, err = retiredMaker.VerifyToken(newToken)
+	require.NoError(t, err)
+
+	_, err = retiredMaker.VerifyToken(oldToken)
+	require.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyringFromConfigKeepsLegacySecret(t *testing.T) {
+	legacyMaker, err := NewPasetoMaker(testKey1)
+	require.NoError(t, err)
+
+	legacyToken, _, err := legacyMaker.CreateToken(uuid.New(), util.RoleUser, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	keyring, err := NewKeyringFromConfig(util.Config{
+		TokenSecret:      testKey1,
+		TokenKeys:        "b:" + testKey2,
+		TokenActiveKeyID: "b",
+	})
+	require.NoError(t, err)
+	maker := NewPasetoMakerFromKeyring(keyring)
+
+	_, err = maker.VerifyToken(legacyToken)
+	require.NoError(t, err)
+
+	_, err = NewKeyringFromConfig(util.Config{
+		TokenKeys: "b:" + testKey2,
+	})
+	require.Error(t, err)
+}
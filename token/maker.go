@@ -0,0 +1,63 @@
+package token
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/google/uuid"
+)
+
+type Maker interface {
+	CreateToken(userID uuid.UUID, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error)
+	VerifyToken(token string) (*Payload, error)
+}
+
+// KeyPublisher is implemented by makers whose tokens can be verified by
+// anyone holding the public keys, without the secret
+type KeyPublisher interface {
+	PublicKeys() []PublicKey
+}
+
+type PublicKey struct {
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	// base64url encoded, without padding
+	Key string `json:"key"`
+}
+
+// NewMaker picks the implementation from TOKEN_MODE. the local mode encrypts
+// tokens with a shared secret, the public one signs them with ed25519 keys so
+// other services can verify them offline. the jwt modes are there for
+// integrations that don't speak paseto, and use the same keys as their
+// paseto counterparts
+func NewMaker(config util.Config) (Maker, error) {
+	switch config.TokenMode {
+	case "public":
+		keyring, err := NewPublicKeyringFromConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		return NewPasetoPublicMaker(keyring), nil
+	case "jwt_hs256":
+		keyring, err := NewKeyringFromConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		return NewJWTMakerHS256(keyring), nil
+	case "jwt_eddsa":
+		keyring, err := NewPublicKeyringFromConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		return NewJWTMakerEdDSA(keyring), nil
+	case "local", "":
+		keyring, err := NewKeyringFromConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		return NewPasetoMakerFromKeyring(keyring), nil
+	default:
+		return nil, fmt.Errorf("unsupported token mode %s", config.TokenMode)
+	}
+}
@@ -0,0 +1,127 @@
+package token
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// testMakers returns one of every maker, so the shared tests cover them all
+func testMakers(t *testing.T) map[string]Maker {
+	keyring, err := NewKeyring(map[string]string{"a": testKey1}, "a")
+	require.NoError(t, err)
+
+	publicKeyring, err := NewPublicKeyring(map[string]string{"a": randomSeed(t)}, "a")
+	require.NoError(t, err)
+
+	pasetoMaker, err := NewPasetoMaker(testKey1)
+	require.NoError(t, err)
+
+	return map[string]Maker{
+		"paseto local":  pasetoMaker,
+		"paseto public": NewPasetoPublicMaker(publicKeyring),
+		"jwt hs256":     NewJWTMakerHS256(keyring),
+		"jwt eddsa":     NewJWTMakerEdDSA(publicKeyring),
+	}
+}
+
+func TestMakers(t *testing.T) {
+	for name, maker := range testMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			id := uuid.New()
+			duration := time.Minute
+
+			issuedAt := time.Now()
+			expiredAt := issuedAt.Add(duration)
+
+			token, p, err := maker.CreateToken(id, util.RoleModerator, TokenTypeAccess, duration)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, p.ID, payload.ID)
+			require.Equal(t, id, payload.UserID)
+			require.Equal(t, util.RoleModerator, payload.Role)
+			require.Equal(t, TokenTypeAccess, payload.Type)
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+			require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+		})
+	}
+}
+
+func TestMakersRefreshToken(t *testing.T) {
+	for name, maker := range testMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			token, _, err := maker.CreateToken(uuid.New(), util.RoleUser, TokenTypeRefresh, time.Minute)
+			require.NoError(t, err)
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, TokenTypeRefresh, payload.Type)
+		})
+	}
+}
+
+func TestMakersExpiredToken(t *testing.T) {
+	for name, maker := range testMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			token, _, err := maker.CreateToken(uuid.New(), util.RoleUser, TokenTypeAccess, -time.Minute)
+			require.NoError(t, err)
+
+			payload, err := maker.VerifyToken(token)
+			require.Error(t, err)
+			require.Nil(t, payload)
+		})
+	}
+}
+
+func TestMakersTamperedToken(t *testing.T) {
+	for name, maker := range testMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			token, _, err := maker.CreateToken(uuid.New(), util.RoleUser, TokenTypeAccess, time.Minute)
+			require.NoError(t, err)
+
+			// flip a character in the middle of the token
+			i := len(token) / 2
+			replacement := "A"
+			if token[i] == 'A' {
+				replacement = "B"
+			}
+			tampered := token[:i] + replacement + token[i+1:]
+
+			payload, err := maker.VerifyToken(tampered)
+			require.Error(t, err)
+			require.Nil(t, payload)
+		})
+	}
+}
+
+func TestJWTMakerRejectsOtherAlgorithms(t *testing.T) {
+	keyring, err := NewKeyring(map[string]string{"a": testKey1}, "a")
+	require.NoError(t, err)
+	maker := NewJWTMakerHS256(keyring)
+
+	payload, err := NewPayload(uuid.New(), util.RoleUser, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, jwtClaims{
+		Payload: *payload,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
+		},
+	})
+	unsigned.Header["kid"] = "a"
+
+	token, err := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(token, "."))
+
+	_, err = maker.VerifyToken(token)
+	require.Error(t, err)
+}
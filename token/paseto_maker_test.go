@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dqrk0jeste/letscube-backend/util"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -18,7 +19,7 @@ func TestPasetoMaker(t *testing.T) {
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, p, err := maker.CreateToken(id, duration)
+	token, p, err := maker.CreateToken(id, util.RoleUser, TokenTypeAccess, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -42,7 +43,7 @@ func TestExpiredPasetoToken(t *testing.T) {
 	maker, err := NewPasetoMaker("dadsdfdsfsdfdsfsdfsdfsdffrkjmbdx")
 	require.NoError(t, err)
 
-	token, _, err := maker.CreateToken(uuid.New(), -time.Minute)
+	token, _, err := maker.CreateToken(uuid.New(), util.RoleUser, TokenTypeAccess, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
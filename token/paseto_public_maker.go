@@ -0,0 +1,65 @@
+package token
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/o1egl/paseto"
+)
+
+const pasetoPublicAlgorithm = "v2.public"
+
+// PasetoPublicMaker signs tokens instead of encrypting them, so keep in mind
+// that the payload is readable by anyone holding the token
+type PasetoPublicMaker struct {
+	paseto  *paseto.V2
+	keyring *PublicKeyring
+}
+
+func NewPasetoPublicMaker(keyring *PublicKeyring) *PasetoPublicMaker {
+	return &PasetoPublicMaker{
+		paseto:  paseto.NewV2(),
+		keyring: keyring,
+	}
+}
+
+func (maker *PasetoPublicMaker) CreateToken(userID uuid.UUID, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID, role, tokenType, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	keyID, privateKey := maker.keyring.activeKey()
+
+	token, err := maker.paseto.Sign(privateKey, payload, tokenFooter{KeyID: keyID})
+	return token, payload, err
+}
+
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	var footer tokenFooter
+	err := paseto.ParseFooter(token, &footer)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := maker.keyring.publicKey(footer.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &Payload{}
+
+	err = maker.paseto.Verify(token, publicKey, payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (maker *PasetoPublicMaker) PublicKeys() []PublicKey {
+	return maker.keyring.publicKeys(pasetoPublicAlgorithm)
+}
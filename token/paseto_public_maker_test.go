@@ -0,0 +1,82 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/dqrk0jeste/letscube-backend/util"
+	"github.com/google/uuid"
+	"github.com/o1egl/paseto"
+	"github.com/stretchr/testify/require"
+)
+
+func randomSeed(t *testing.T) string {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(privateKey.Seed())
+}
+
+func TestPasetoPublicMaker(t *testing.T) {
+	keyring, err := NewPublicKeyring(map[string]string{"a": randomSeed(t)}, "a")
+	require.NoError(t, err)
+	maker := NewPasetoPublicMaker(keyring)
+
+	id := uuid.New()
+
+	token, p, err := maker.CreateToken(id, util.RoleUser, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, p.ID, payload.ID)
+	require.Equal(t, id, payload.UserID)
+	require.WithinDuration(t, p.ExpiredAt, payload.ExpiredAt, time.Second)
+}
+
+func TestExpiredPasetoPublicToken(t *testing.T) {
+	keyring, err := NewPublicKeyring(map[string]string{"a": randomSeed(t)}, "a")
+	require.NoError(t, err)
+	maker := NewPasetoPublicMaker(keyring)
+
+	token, _, err := maker.CreateToken(uuid.New(), util.RoleUser, TokenTypeAccess, -time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.Error(t, err)
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicMakerKeys(t *testing.T) {
+	keyring, err := NewPublicKeyring(map[string]string{"a": randomSeed(t), "b": randomSeed(t)}, "b")
+	require.NoError(t, err)
+	maker := NewPasetoPublicMaker(keyring)
+
+	publicKeys := maker.PublicKeys()
+	require.Len(t, publicKeys, 2)
+	require.Equal(t, "a", publicKeys[0].KeyID)
+	require.Equal(t, "b", publicKeys[1].KeyID)
+
+	// other services only get the published key, so it has to be enough on its own
+	token, _, err := maker.CreateToken(uuid.New(), util.RoleUser, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	publicKey, err := base64.RawURLEncoding.DecodeString(publicKeys[1].Key)
+	require.NoError(t, err)
+
+	payload := &Payload{}
+	err = paseto.NewV2().Verify(token, ed25519.PublicKey(publicKey), payload, nil)
+	require.NoError(t, err)
+
+	// a token signed by a key the keyring doesn't have
+	otherKeyring, err := NewPublicKeyring(map[string]string{"c": randomSeed(t)}, "c")
+	require.NoError(t, err)
+
+	token, _, err = NewPasetoPublicMaker(otherKeyring).CreateToken(uuid.New(), util.RoleUser, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrUnknownKey)
+}
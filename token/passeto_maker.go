@@ -1,45 +1,73 @@
 package token
 
 import (
-	"fmt"
 	"time"
 
-	"github.com/aead/chacha20poly1305"
 	"github.com/google/uuid"
 	"github.com/o1egl/paseto"
 )
 
 type PasetoMaker struct {
-	paseto       *paseto.V2
-	symmetricKey []byte
+	paseto  *paseto.V2
+	keyring *Keyring
+}
+
+// tokenFooter is not encrypted, only authenticated, so it must never hold
+// anything but the key id
+type tokenFooter struct {
+	KeyID string `json:"kid"`
 }
 
 func NewPasetoMaker(symmetricKey string) (*PasetoMaker, error) {
-	if len(symmetricKey) != chacha20poly1305.KeySize {
-		return nil, fmt.Errorf("invalid key size: must be exactly %d characters long", chacha20poly1305.KeySize)
+	keyring, err := NewKeyring(map[string]string{legacyKeyID: symmetricKey}, legacyKeyID)
+	if err != nil {
+		return nil, err
 	}
 
-	maker := &PasetoMaker{
-		paseto:       paseto.NewV2(),
-		symmetricKey: []byte(symmetricKey),
-	}
+	return NewPasetoMakerFromKeyring(keyring), nil
+}
 
-	return maker, nil
+func NewPasetoMakerFromKeyring(keyring *Keyring) *PasetoMaker {
+	return &PasetoMaker{
+		paseto:  paseto.NewV2(),
+		keyring: keyring,
+	}
 }
 
-func (maker *PasetoMaker) CreateToken(userID uuid.UUID, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(userID, duration)
+func (maker *PasetoMaker) CreateToken(userID uuid.UUID, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID, role, tokenType, duration)
 	if err != nil {
 		return "", nil, err
 	}
-	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+
+	keyID, key := maker.keyring.activeKey()
+
+	// the legacy key keeps creating tokens without a footer, so they stay
+	// exactly the same as before keyrings
+	var footer interface{}
+	if keyID != legacyKeyID {
+		footer = tokenFooter{KeyID: keyID}
+	}
+
+	token, err := maker.paseto.Encrypt(key, payload, footer)
 	return token, payload, err
 }
 
 func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
+	var footer tokenFooter
+	err := paseto.ParseFooter(token, &footer)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := maker.keyring.key(footer.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
 	payload := &Payload{}
 
-	err := maker.paseto.Decrypt(token, maker.symmetricKey, payload, nil)
+	err = maker.paseto.Decrypt(token, key, payload, nil)
 	if err != nil {
 		return nil, err
 	}
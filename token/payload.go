@@ -7,14 +7,25 @@ import (
 	"github.com/google/uuid"
 )
 
+// TokenType tells access and refresh tokens apart. both come from the same
+// maker, so without it a refresh token would pass as an access token
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+)
+
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    uuid.UUID `json:"user_id"`
+	Role      string    `json:"role"`
+	Type      TokenType `json:"type"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-func NewPayload(userID uuid.UUID, duration time.Duration) (*Payload, error) {
+func NewPayload(userID uuid.UUID, role string, tokenType TokenType, duration time.Duration) (*Payload, error) {
 	id, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -23,6 +34,8 @@ func NewPayload(userID uuid.UUID, duration time.Duration) (*Payload, error) {
 	payload := &Payload{
 		ID:        id,
 		UserID:    userID,
+		Role:      role,
+		Type:      tokenType,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
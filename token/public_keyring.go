@@ -0,0 +1,91 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dqrk0jeste/letscube-backend/util"
+)
+
+// PublicKeyring is the asymmetric counterpart of Keyring. tokens are signed
+// with the active private key and verified with the public key their footer names
+type PublicKeyring struct {
+	privateKeys map[string]ed25519.PrivateKey
+	activeKeyID string
+}
+
+// NewPublicKeyring takes base64 encoded ed25519 seeds
+func NewPublicKeyring(seeds map[string]string, activeKeyID string) (*PublicKeyring, error) {
+	keyring := &PublicKeyring{
+		privateKeys: make(map[string]ed25519.PrivateKey, len(seeds)),
+		activeKeyID: activeKeyID,
+	}
+
+	for id, encodedSeed := range seeds {
+		seed, err := base64.StdEncoding.DecodeString(encodedSeed)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", id, err)
+		}
+		if len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("invalid size of key %q: must be a base64 encoded %d byte seed", id, ed25519.SeedSize)
+		}
+		keyring.privateKeys[id] = ed25519.NewKeyFromSeed(seed)
+	}
+
+	if _, ok := keyring.privateKeys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active key %q is not in the keyring", activeKeyID)
+	}
+
+	return keyring, nil
+}
+
+// NewPublicKeyringFromConfig builds the keyring from TOKEN_PRIVATE_KEYS, in the
+// same "id:seed,id:seed" format as TOKEN_KEYS, and TOKEN_ACTIVE_KEY_ID
+func NewPublicKeyringFromConfig(config util.Config) (*PublicKeyring, error) {
+	if config.TokenPrivateKeys == "" {
+		return nil, errors.New("TOKEN_PRIVATE_KEYS is required in the public and jwt_eddsa token modes")
+	}
+
+	seeds, err := ParseKeys(config.TokenPrivateKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPublicKeyring(seeds, config.TokenActiveKeyID)
+}
+
+func (keyring *PublicKeyring) activeKey() (string, ed25519.PrivateKey) {
+	return keyring.activeKeyID, keyring.privateKeys[keyring.activeKeyID]
+}
+
+func (keyring *PublicKeyring) publicKey(id string) (ed25519.PublicKey, error) {
+	privateKey, ok := keyring.privateKeys[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return privateKey.Public().(ed25519.PublicKey), nil
+}
+
+// publicKeys are sorted by id, so they are published in a stable order
+func (keyring *PublicKeyring) publicKeys(algorithm string) []PublicKey {
+	ids := make([]string, 0, len(keyring.privateKeys))
+	for id := range keyring.privateKeys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	publicKeys := make([]PublicKey, 0, len(ids))
+	for _, id := range ids {
+		publicKey, _ := keyring.publicKey(id)
+		publicKeys = append(publicKeys, PublicKey{
+			KeyID:     id,
+			Algorithm: algorithm,
+			Key:       base64.RawURLEncoding.EncodeToString(publicKey),
+		})
+	}
+
+	return publicKeys
+}
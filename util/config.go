@@ -7,15 +7,52 @@ import (
 )
 
 type Config struct {
-	DatabaseSource        string        `mapstructure:"DB_SOURCE"`
-	DatabaseDriver        string        `mapstructure:"DB_DRIVER"`
-	ServerAddress         string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSecret           string        `mapstructure:"TOKEN_SECRET"`
-	AccessTokenDuration   time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration  time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	AWS_REGION            string        `mapstructure:"AWS_REGION"`
-	AWS_ACCESS_KEY_ID     string        `mapstructure:"AWS_ACCESS_KEY_ID"`
-	AWS_SECRET_ACCESS_KEY string        `mapstructure:"AWS_SECRET_ACCESS_KEY"`
+	DatabaseSource                 string        `mapstructure:"DB_SOURCE"`
+	DatabaseDriver                 string        `mapstructure:"DB_DRIVER"`
+	ServerAddress                  string        `mapstructure:"SERVER_ADDRESS"`
+	TokenMode                      string        `mapstructure:"TOKEN_MODE"`
+	TokenSecret                    string        `mapstructure:"TOKEN_SECRET"`
+	TokenKeys                      string        `mapstructure:"TOKEN_KEYS"`
+	TokenActiveKeyID               string        `mapstructure:"TOKEN_ACTIVE_KEY_ID"`
+	TokenPrivateKeys               string        `mapstructure:"TOKEN_PRIVATE_KEYS"`
+	AccessTokenDuration            time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration           time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	AWS_REGION                     string        `mapstructure:"AWS_REGION"`
+	AWS_ACCESS_KEY_ID              string        `mapstructure:"AWS_ACCESS_KEY_ID"`
+	AWS_SECRET_ACCESS_KEY          string        `mapstructure:"AWS_SECRET_ACCESS_KEY"`
+	AppURL                         string        `mapstructure:"APP_URL"`
+	MailSender                     string        `mapstructure:"MAIL_SENDER"`
+	MailFrom                       string        `mapstructure:"MAIL_FROM"`
+	MailLogPath                    string        `mapstructure:"MAIL_LOG_PATH"`
+	SMTPHost                       string        `mapstructure:"SMTP_HOST"`
+	SMTPPort                       string        `mapstructure:"SMTP_PORT"`
+	SMTPUsername                   string        `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword                   string        `mapstructure:"SMTP_PASSWORD"`
+	EmailVerificationTokenDuration time.Duration `mapstructure:"EMAIL_VERIFICATION_TOKEN_DURATION"`
+	PasswordResetTokenDuration     time.Duration `mapstructure:"PASSWORD_RESET_TOKEN_DURATION"`
+	MfaTokenDuration               time.Duration `mapstructure:"MFA_TOKEN_DURATION"`
+	TotpIssuer                     string        `mapstructure:"TOTP_ISSUER"`
+	PasswordMinLength              int           `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMaxLength              int           `mapstructure:"PASSWORD_MAX_LENGTH"`
+	PasswordRejectCommon           bool          `mapstructure:"PASSWORD_REJECT_COMMON"`
+	LoginAttemptStore              string        `mapstructure:"LOGIN_ATTEMPT_STORE"`
+	LoginMaxAttempts               int           `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginIPMaxAttempts             int           `mapstructure:"LOGIN_IP_MAX_ATTEMPTS"`
+	LoginBaseLockout               time.Duration `mapstructure:"LOGIN_BASE_LOCKOUT"`
+	LoginMaxLockout                time.Duration `mapstructure:"LOGIN_MAX_LOCKOUT"`
+	LoginAttemptResetAfter         time.Duration `mapstructure:"LOGIN_ATTEMPT_RESET_AFTER"`
+	AccountDeletionGracePeriod     time.Duration `mapstructure:"ACCOUNT_DELETION_GRACE_PERIOD"`
+	AccountPurgeInterval           time.Duration `mapstructure:"ACCOUNT_PURGE_INTERVAL"`
+	DataExportDuration             time.Duration `mapstructure:"DATA_EXPORT_DURATION"`
+	FeedRecencyHalfLife            time.Duration `mapstructure:"FEED_RECENCY_HALF_LIFE"`
+	FeedReactionWeight             float64       `mapstructure:"FEED_REACTION_WEIGHT"`
+	FeedCommentWeight              float64       `mapstructure:"FEED_COMMENT_WEIGHT"`
+	FeedAffinityWeight             float64       `mapstructure:"FEED_AFFINITY_WEIGHT"`
+	FeedDiscoveryFactor            float64       `mapstructure:"FEED_DISCOVERY_FACTOR"`
+	FeedDiscoveryShare             float64       `mapstructure:"FEED_DISCOVERY_SHARE"`
+	FeedCandidateWindow            time.Duration `mapstructure:"FEED_CANDIDATE_WINDOW"`
+	FeedCandidateLimit             int32         `mapstructure:"FEED_CANDIDATE_LIMIT"`
+	TimelineFanoutMaxFollowers     int64         `mapstructure:"TIMELINE_FANOUT_MAX_FOLLOWERS"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -23,6 +60,34 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigName("app")
 	viper.SetConfigType("env")
 
+	viper.SetDefault("TOKEN_MODE", "local")
+	viper.SetDefault("MAIL_SENDER", "log")
+	viper.SetDefault("EMAIL_VERIFICATION_TOKEN_DURATION", 24*time.Hour)
+	viper.SetDefault("PASSWORD_RESET_TOKEN_DURATION", 30*time.Minute)
+	viper.SetDefault("MFA_TOKEN_DURATION", 5*time.Minute)
+	viper.SetDefault("TOTP_ISSUER", "letscube")
+	viper.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	viper.SetDefault("PASSWORD_MAX_LENGTH", 72)
+	viper.SetDefault("PASSWORD_REJECT_COMMON", true)
+	viper.SetDefault("LOGIN_ATTEMPT_STORE", "memory")
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_IP_MAX_ATTEMPTS", 20)
+	viper.SetDefault("LOGIN_BASE_LOCKOUT", 30*time.Second)
+	viper.SetDefault("LOGIN_MAX_LOCKOUT", time.Hour)
+	viper.SetDefault("LOGIN_ATTEMPT_RESET_AFTER", time.Hour)
+	viper.SetDefault("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour)
+	viper.SetDefault("ACCOUNT_PURGE_INTERVAL", time.Hour)
+	viper.SetDefault("DATA_EXPORT_DURATION", 7*24*time.Hour)
+	viper.SetDefault("FEED_RECENCY_HALF_LIFE", 6*time.Hour)
+	viper.SetDefault("FEED_REACTION_WEIGHT", 1.0)
+	viper.SetDefault("FEED_COMMENT_WEIGHT", 1.5)
+	viper.SetDefault("FEED_AFFINITY_WEIGHT", 2.0)
+	viper.SetDefault("FEED_DISCOVERY_FACTOR", 0.5)
+	viper.SetDefault("FEED_DISCOVERY_SHARE", 0.2)
+	viper.SetDefault("FEED_CANDIDATE_WINDOW", 3*24*time.Hour)
+	viper.SetDefault("FEED_CANDIDATE_LIMIT", 500)
+	viper.SetDefault("TIMELINE_FANOUT_MAX_FOLLOWERS", 10000)
+
 	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
@@ -0,0 +1,56 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the last row of a page, the next page starts right after it. lists
+// ordered by time only need CreatedAt and ID, the id breaks ties between rows
+// created at the same moment. Rank, Score and Key are for lists sorted by
+// something else first, like the number of replies, a search rank or the username
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"i"`
+	Rank      int64     `json:"r,omitempty"`
+	Score     float64   `json:"s,omitempty"`
+	Key       string    `json:"k,omitempty"`
+}
+
+// Encode turns the cursor into the opaque string clients send back. it is not
+// signed, a tampered cursor only moves the caller around lists it can already read
+func (cursor Cursor) Encode() string {
+	return EncodeCursor(cursor)
+}
+
+func DecodeCursor(encoded string) (Cursor, error) {
+	var cursor Cursor
+	err := DecodeCursorInto(encoded, &cursor)
+	return cursor, err
+}
+
+// EncodeCursor is Encode for lists that need a cursor shaped differently than
+// Cursor, like the ranked feed
+func EncodeCursor(cursor any) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursorInto(encoded string, cursor any) error {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return ErrInvalidCursor
+	}
+
+	return nil
+}
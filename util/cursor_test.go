@@ -0,0 +1,33 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	cursor := Cursor{
+		CreatedAt: time.Now().UTC(),
+		ID:        uuid.New(),
+		Rank:      7,
+		Score:     0.0759909,
+		Key:       "feliks",
+	}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	require.Equal(t, cursor.ID, decoded.ID)
+	require.Equal(t, cursor.Rank, decoded.Rank)
+	require.Equal(t, cursor.Score, decoded.Score)
+	require.Equal(t, cursor.Key, decoded.Key)
+
+	_, err = DecodeCursor("not a cursor")
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = DecodeCursor("bm90IGpzb24")
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -0,0 +1,74 @@
+package util
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var (
+	commonPasswords     map[string]struct{}
+	commonPasswordsOnce sync.Once
+)
+
+var (
+	ErrPasswordIsCommon       = errors.New("password is too common")
+	ErrPasswordMatchesAccount = errors.New("password can't be the same as the username")
+)
+
+type PasswordPolicy struct {
+	MinLength    int
+	MaxLength    int
+	RejectCommon bool
+}
+
+func NewPasswordPolicy(config Config) PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:    config.PasswordMinLength,
+		MaxLength:    config.PasswordMaxLength,
+		RejectCommon: config.PasswordRejectCommon,
+	}
+}
+
+// Validate checks the password against the policy. the max length should never
+// be set above 72, bcrypt ignores everything after that.
+func (policy PasswordPolicy) Validate(password string, username string) error {
+	if len(password) < policy.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", policy.MinLength)
+	}
+
+	if policy.MaxLength > 0 && len(password) > policy.MaxLength {
+		return fmt.Errorf("password must be at most %d characters long", policy.MaxLength)
+	}
+
+	if username != "" && strings.EqualFold(password, username) {
+		return ErrPasswordMatchesAccount
+	}
+
+	if policy.RejectCommon && isCommonPassword(password) {
+		return ErrPasswordIsCommon
+	}
+
+	return nil
+}
+
+func isCommonPassword(password string) bool {
+	commonPasswordsOnce.Do(func() {
+		commonPasswords = make(map[string]struct{})
+
+		for _, line := range strings.Split(commonPasswordsFile, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				commonPasswords[strings.ToLower(line)] = struct{}{}
+			}
+		}
+	})
+
+	_, ok := commonPasswords[strings.ToLower(password)]
+	return ok
+}
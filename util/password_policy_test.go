@@ -0,0 +1,48 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:    8,
+		MaxLength:    72,
+		RejectCommon: true,
+	}
+
+	testCases := []struct {
+		name     string
+		password string
+		username string
+		ok       bool
+	}{
+		{"valid", "solved in 7.08 seconds", "darko", true},
+		{"too short", "r2d2c3", "darko", false},
+		{"too long", string(make([]byte, 73)), "darko", false},
+		{"common", "password123", "darko", false},
+		{"common with different case", "SpeedCubing", "darko", false},
+		{"same as username", "darkodarko", "DarkoDarko", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := policy.Validate(testCase.password, testCase.username)
+			if testCase.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyWithoutCommonCheck(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength: 8,
+	}
+
+	require.NoError(t, policy.Validate("password123", "darko"))
+}
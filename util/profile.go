@@ -0,0 +1,39 @@
+package util
+
+import (
+	"regexp"
+	"slices"
+)
+
+// WcaEvents are the ids the WCA uses for its current events
+var WcaEvents = []string{
+	"333",
+	"222",
+	"444",
+	"555",
+	"666",
+	"777",
+	"333bf",
+	"333fm",
+	"333oh",
+	"clock",
+	"minx",
+	"pyram",
+	"skewb",
+	"sq1",
+	"444bf",
+	"555bf",
+	"333mbf",
+}
+
+// a WCA ID is the year of the first competition, the first four letters of the
+// last name and a two digit counter, like 2009ZEMD01
+var wcaIDPattern = regexp.MustCompile(`^[0-9]{4}[A-Z]{4}[0-9]{2}$`)
+
+func IsValidWcaID(id string) bool {
+	return wcaIDPattern.MatchString(id)
+}
+
+func IsValidWcaEvent(event string) bool {
+	return slices.Contains(WcaEvents, event)
+}
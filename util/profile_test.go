@@ -0,0 +1,28 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidWcaID(t *testing.T) {
+	require.True(t, IsValidWcaID("2009ZEMD01"))
+	require.True(t, IsValidWcaID("2016PARK01"))
+
+	require.False(t, IsValidWcaID(""))
+	require.False(t, IsValidWcaID("2009zemd01"))
+	require.False(t, IsValidWcaID("2009ZEM01"))
+	require.False(t, IsValidWcaID("2009ZEMD001"))
+	require.False(t, IsValidWcaID("09ZEMD01"))
+	require.False(t, IsValidWcaID(" 2009ZEMD01"))
+}
+
+func TestIsValidWcaEvent(t *testing.T) {
+	require.True(t, IsValidWcaEvent("333"))
+	require.True(t, IsValidWcaEvent("sq1"))
+
+	require.False(t, IsValidWcaEvent(""))
+	require.False(t, IsValidWcaEvent("3x3"))
+	require.False(t, IsValidWcaEvent("magic"))
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// GenerateRandomToken returns a url safe string made from numberOfBytes random bytes
+func GenerateRandomToken(numberOfBytes int) (string, error) {
+	bytes := make([]byte, numberOfBytes)
+
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashToken is used for one time tokens we store, they are random enough that
+// a plain sha256 is fine and, unlike bcrypt, it lets us look them up by hash
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
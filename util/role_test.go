@@ -0,0 +1,24 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasRole(t *testing.T) {
+	require.True(t, HasRole(RoleUser, RoleUser))
+	require.False(t, HasRole(RoleUser, RoleModerator))
+	require.False(t, HasRole(RoleUser, RoleAdmin))
+
+	require.True(t, HasRole(RoleModerator, RoleUser))
+	require.True(t, HasRole(RoleModerator, RoleModerator))
+	require.False(t, HasRole(RoleModerator, RoleAdmin))
+
+	require.True(t, HasRole(RoleAdmin, RoleUser))
+	require.True(t, HasRole(RoleAdmin, RoleModerator))
+	require.True(t, HasRole(RoleAdmin, RoleAdmin))
+
+	require.False(t, HasRole("", RoleUser))
+	require.False(t, HasRole("superuser", RoleUser))
+}
@@ -0,0 +1,15 @@
+package util
+
+import (
+	"html"
+	"strings"
+)
+
+var snippetTags = strings.NewReplacer("&lt;mark&gt;", "<mark>", "&lt;/mark&gt;", "</mark>")
+
+// HighlightSnippet makes a ts_headline snippet safe to render as html. the text
+// comes from users, so all of it is escaped and only the <mark> tags around the
+// matches are let back in
+func HighlightSnippet(snippet string) string {
+	return snippetTags.Replace(html.EscapeString(snippet))
+}
@@ -0,0 +1,16 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightSnippet(t *testing.T) {
+	require.Equal(t, "new <mark>pb</mark> on 3x3", HighlightSnippet("new <mark>pb</mark> on 3x3"))
+	require.Equal(t,
+		"&lt;script&gt;alert(1)&lt;/script&gt; <mark>sub</mark> 5 &amp; more",
+		HighlightSnippet("<script>alert(1)</script> <mark>sub</mark> 5 & more"),
+	)
+	require.Equal(t, "", HighlightSnippet(""))
+}
@@ -0,0 +1,83 @@
+package util
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from RFC 6238 appendix B, truncated to six digits
+func TestGenerateTOTPCode(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, testCase := range testCases {
+		code, err := GenerateTOTPCode(secret, TOTPCounter(time.Unix(testCase.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, testCase.code, code)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	counter := TOTPCounter(now)
+
+	code, err := GenerateTOTPCode(secret, counter)
+	require.NoError(t, err)
+
+	matched, ok := ValidateTOTP(secret, code, now)
+	require.True(t, ok)
+	require.Equal(t, counter, matched)
+
+	previousCode, err := GenerateTOTPCode(secret, counter-1)
+	require.NoError(t, err)
+
+	matched, ok = ValidateTOTP(secret, previousCode, now)
+	require.True(t, ok)
+	require.Equal(t, counter-1, matched)
+
+	oldCode, err := GenerateTOTPCode(secret, counter-3)
+	require.NoError(t, err)
+
+	_, ok = ValidateTOTP(secret, oldCode, now)
+	require.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, "12345", now)
+	require.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("letscube", "darko", "JBSWY3DPEHPK3PXP")
+
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/letscube:darko?"))
+	require.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	require.Contains(t, uri, "issuer=letscube")
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+
+	for _, code := range codes {
+		require.Len(t, code, 11)
+		require.Equal(t, code, NormalizeRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", ""))))
+	}
+}